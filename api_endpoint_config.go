@@ -3,8 +3,8 @@ package remoteconfig
 import "fmt"
 
 type APIEndpointConfig struct {
-	BasePath *string `json:"base_path,omitempty"`
-	SubPath  *string `json:"sub_path,omitempty"`
+	BasePath *string `json:"base_path,omitempty" yaml:"base_path,omitempty"`
+	SubPath  *string `json:"sub_path,omitempty" yaml:"sub_path,omitempty"`
 }
 
 func (c APIEndpointConfig) GetFullPath() string {
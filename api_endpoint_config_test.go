@@ -1,7 +1,6 @@
 package remoteconfig
 
 import (
-	"errors"
 	"fmt"
 	"testing"
 
@@ -54,7 +53,7 @@ func (s *APIEndpointConfigSuite) TestValidateErrorBasePathNotSet() {
 
 	err := validateConfigWithReflection(a)
 	assert.NotNil(s.T(), err)
-	assert.Equal(s.T(), errors.New("Field: BasePath, not set"), err)
+	assert.EqualError(s.T(), err, "Field: BasePath, not set")
 }
 
 func (s *APIEndpointConfigSuite) TestValidateErrorSubPathNotSet() {
@@ -67,7 +66,7 @@ func (s *APIEndpointConfigSuite) TestValidateErrorSubPathNotSet() {
 
 	err := validateConfigWithReflection(a)
 	assert.NotNil(s.T(), err)
-	assert.Equal(s.T(), errors.New("Field: SubPath, not set"), err)
+	assert.EqualError(s.T(), err, "Field: SubPath, not set")
 }
 
 func (s *APIEndpointConfigSuite) TestGetFullPath() {
@@ -0,0 +1,24 @@
+package remoteconfig
+
+import "os"
+
+type AWSCredentials struct {
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+}
+
+// Reads credentials from the standard AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY
+// and AWS_SESSION_TOKEN environment variables.
+func AWSCredentialsFromEnv() AWSCredentials {
+	return AWSCredentials{
+		AccessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+		SecretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+		SessionToken:    os.Getenv("AWS_SESSION_TOKEN"),
+	}
+}
+
+// Anonymous credentials result in unsigned requests, useful for public buckets.
+func (c AWSCredentials) IsAnonymous() bool {
+	return c.AccessKeyID == "" && c.SecretAccessKey == ""
+}
@@ -0,0 +1,142 @@
+package remoteconfig
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	AWS_SIGV4_ALGORITHM    string = "AWS4-HMAC-SHA256"
+	AWS_SIGV4_TIME_FORMAT  string = "20060102T150405Z"
+	AWS_SIGV4_DATE_FORMAT  string = "20060102"
+	AWS_EMPTY_PAYLOAD_HASH string = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+)
+
+// Signs an HTTP request in place using AWS Signature Version 4.
+// Signs the host header, any x-amz-* headers and the range / content-type headers if present.
+func signAWSRequestV4(req *http.Request, creds AWSCredentials, region AWSRegion, service string, payloadHash string, t time.Time) {
+	t = t.UTC()
+	amzDate := t.Format(AWS_SIGV4_TIME_FORMAT)
+	date := t.Format(AWS_SIGV4_DATE_FORMAT)
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	if creds.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", creds.SessionToken)
+	}
+
+	signedHeaders, canonicalHeaders := canonicalAWSHeaders(req)
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		canonicalAWSURI(req.URL),
+		canonicalAWSQuery(req.URL.Query()),
+		canonicalHeaders,
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := strings.Join([]string{date, string(region), service, "aws4_request"}, "/")
+	stringToSign := strings.Join([]string{
+		AWS_SIGV4_ALGORITHM,
+		amzDate,
+		scope,
+		hexSHA256([]byte(canonicalRequest)),
+	}, "\n")
+
+	signingKey := hmacSHA256([]byte("AWS4"+creds.SecretAccessKey), []byte(date))
+	signingKey = hmacSHA256(signingKey, []byte(region))
+	signingKey = hmacSHA256(signingKey, []byte(service))
+	signingKey = hmacSHA256(signingKey, []byte("aws4_request"))
+	signature := hex.EncodeToString(hmacSHA256(signingKey, []byte(stringToSign)))
+
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		AWS_SIGV4_ALGORITHM, creds.AccessKeyID, scope, signedHeaders, signature))
+}
+
+func canonicalAWSHeaders(req *http.Request) (string, string) {
+	host := req.Host
+	if host == "" {
+		host = req.URL.Host
+	}
+
+	headers := map[string]string{"host": strings.TrimSpace(host)}
+	for k, v := range req.Header {
+		lk := strings.ToLower(k)
+		if strings.HasPrefix(lk, "x-amz-") || lk == "range" || lk == "content-type" || lk == "content-md5" {
+			headers[lk] = strings.TrimSpace(strings.Join(v, ","))
+		}
+	}
+
+	keys := make([]string, 0, len(headers))
+	for k := range headers {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var canonical strings.Builder
+	for _, k := range keys {
+		canonical.WriteString(k + ":" + headers[k] + "\n")
+	}
+
+	return strings.Join(keys, ";"), canonical.String()
+}
+
+func canonicalAWSURI(u *url.URL) string {
+	path := u.EscapedPath()
+	if path == "" {
+		return "/"
+	}
+	return path
+}
+
+func canonicalAWSQuery(values url.Values) string {
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	pairs := []string{}
+	for _, k := range keys {
+		vs := append([]string{}, values[k]...)
+		sort.Strings(vs)
+		for _, v := range vs {
+			pairs = append(pairs, awsURIEncode(k, true)+"="+awsURIEncode(v, true))
+		}
+	}
+	return strings.Join(pairs, "&")
+}
+
+// URI encodes a string per the SigV4 rules, only unreserved characters are left as is.
+func awsURIEncode(s string, encodeSlash bool) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if (c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9') ||
+			c == '-' || c == '_' || c == '.' || c == '~' || (c == '/' && !encodeSlash) {
+			b.WriteByte(c)
+		} else {
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+func hexSHA256(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data []byte) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write(data)
+	return h.Sum(nil)
+}
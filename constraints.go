@@ -0,0 +1,236 @@
+package remoteconfig
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Checks a value against a constraint from a remoteconfig tag, returning an error describing why it failed.
+// Pointers are dereferenced, a nil pointer passes as required fields are checked separately.
+func checkConstraint(v reflect.Value, c fieldConstraint) error {
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+
+	switch c.Rule {
+	case VALIDATION_RULE_MIN, VALIDATION_RULE_MAX, VALIDATION_RULE_LEN:
+		return checkBound(v, c)
+
+	case VALIDATION_RULE_ONE_OF:
+		options := strings.Split(c.Param, tagOneOfSeparator)
+		value := fmt.Sprint(v.Interface())
+		for _, option := range options {
+			if value == option {
+				return nil
+			}
+		}
+		return fmt.Errorf("must be one of %s", strings.Join(options, ", "))
+
+	case VALIDATION_RULE_REGEX:
+		if v.Kind() != reflect.String {
+			return fmt.Errorf("%s rule is not supported for type %s", c.Rule, v.Type())
+		}
+		re := c.regex
+		if re == nil {
+			var err error
+			if re, err = regexp.Compile(c.Param); err != nil {
+				return fmt.Errorf("invalid %s rule parameter '%s', with error, %w", c.Rule, c.Param, err)
+			}
+		}
+		if !re.MatchString(v.String()) {
+			return fmt.Errorf("must match %s", c.Param)
+		}
+	}
+
+	return nil
+}
+
+// Checks a min, max or len constraint. Strings, slices, arrays and maps are checked by length, numbers by value.
+// Durations take a duration parameter, i.e. max=1h.
+func checkBound(v reflect.Value, c fieldConstraint) error {
+	invalidParam := func(err error) error {
+		return fmt.Errorf("invalid %s rule parameter '%s', with error, %w", c.Rule, c.Param, err)
+	}
+
+	// Compares the value to the parameter, -1 when less, 0 when equal and 1 when greater
+	var cmp int
+	subject := ""
+
+	switch v.Kind() {
+	case reflect.String, reflect.Slice, reflect.Array, reflect.Map:
+		length := v.Len()
+		if v.Kind() == reflect.String {
+			length = utf8.RuneCountInString(v.String())
+		}
+		limit, err := strconv.Atoi(c.Param)
+		if err != nil {
+			return invalidParam(err)
+		}
+		cmp = compareInt64(int64(length), int64(limit))
+		subject = "length "
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if c.Rule == VALIDATION_RULE_LEN {
+			return fmt.Errorf("%s rule is not supported for type %s", c.Rule, v.Type())
+		}
+		var limit int64
+		var err error
+		if v.Type() == durationType {
+			var d time.Duration
+			d, err = time.ParseDuration(c.Param)
+			limit = int64(d)
+		} else {
+			limit, err = strconv.ParseInt(c.Param, 10, 64)
+		}
+		if err != nil {
+			return invalidParam(err)
+		}
+		cmp = compareInt64(v.Int(), limit)
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if c.Rule == VALIDATION_RULE_LEN {
+			return fmt.Errorf("%s rule is not supported for type %s", c.Rule, v.Type())
+		}
+		limit, err := strconv.ParseUint(c.Param, 10, 64)
+		if err != nil {
+			return invalidParam(err)
+		}
+		switch {
+		case v.Uint() < limit:
+			cmp = -1
+		case v.Uint() > limit:
+			cmp = 1
+		}
+
+	case reflect.Float32, reflect.Float64:
+		if c.Rule == VALIDATION_RULE_LEN {
+			return fmt.Errorf("%s rule is not supported for type %s", c.Rule, v.Type())
+		}
+		limit, err := strconv.ParseFloat(c.Param, 64)
+		if err != nil {
+			return invalidParam(err)
+		}
+		switch {
+		case v.Float() < limit:
+			cmp = -1
+		case v.Float() > limit:
+			cmp = 1
+		}
+
+	default:
+		return fmt.Errorf("%s rule is not supported for type %s", c.Rule, v.Type())
+	}
+
+	switch {
+	case c.Rule == VALIDATION_RULE_MIN && cmp < 0:
+		return fmt.Errorf("%smust be at least %s", subject, c.Param)
+	case c.Rule == VALIDATION_RULE_MAX && cmp > 0:
+		return fmt.Errorf("%smust be at most %s", subject, c.Param)
+	case c.Rule == VALIDATION_RULE_LEN && cmp != 0:
+		return fmt.Errorf("%smust be %s", subject, c.Param)
+	}
+	return nil
+}
+
+func compareInt64(a int64, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// Checks a cross-field rule, which depends on a sibling field of the struct holding the field,
+// for a field promoted from an embedded struct that's the outer struct it's promoted to.
+// Siblings are named by their Go field name, path is the path of the struct, used to name the sibling in errors.
+// An expr rule is evaluated with self set to the field and parent to the struct.
+func checkCrossFieldConstraint(parent reflect.Value, v reflect.Value, c fieldConstraint, path string) error {
+	if c.Rule == VALIDATION_RULE_EXPR {
+		e := c.expr
+		if e == nil {
+			var err error
+			if e, err = parseExpr(c.Param); err != nil {
+				return fmt.Errorf("invalid %s rule parameter '%s', with error, %w", c.Rule, c.Param, err)
+			}
+		}
+		return checkExpr(e, v, parent)
+	}
+
+	siblingName := c.Param
+	expected := ""
+	if c.Rule == VALIDATION_RULE_REQUIRED_IF {
+		i := strings.Index(c.Param, tagFieldValueSeparator)
+		if i < 0 {
+			return fmt.Errorf("invalid %s rule parameter '%s', expected Field:value", c.Rule, c.Param)
+		}
+		siblingName, expected = c.Param[:i], c.Param[i+1:]
+	}
+
+	sibling, ok := exportedFieldByName(parent, siblingName)
+	if !ok {
+		return fmt.Errorf("%s rule references unknown field %s", c.Rule, siblingName)
+	}
+	// A sibling promoted through a nil embedded pointer isn't set, and each rule needs it set
+	if !sibling.IsValid() {
+		return nil
+	}
+	siblingPath := joinFieldPath(path, siblingName)
+
+	switch c.Rule {
+	case VALIDATION_RULE_REQUIRED_WITH:
+		if isFieldSet(sibling) && !isFieldSet(v) {
+			return fmt.Errorf("required when %s is set", siblingPath)
+		}
+	case VALIDATION_RULE_EXCLUDED_WITH:
+		if isFieldSet(sibling) && isFieldSet(v) {
+			return fmt.Errorf("must not be set when %s is set", siblingPath)
+		}
+	case VALIDATION_RULE_REQUIRED_IF:
+		if isFieldSet(sibling) && fmt.Sprint(reflect.Indirect(sibling).Interface()) == expected && !isFieldSet(v) {
+			return fmt.Errorf("required when %s is %s", siblingPath, expected)
+		}
+	}
+
+	return nil
+}
+
+// Looks up an exported field of a struct by its Go name, including fields promoted from embedded structs.
+// Returns false for an unknown field, and an invalid Value when an embedded pointer on the way to it is nil.
+func exportedFieldByName(v reflect.Value, name string) (reflect.Value, bool) {
+	field, ok := v.Type().FieldByName(name)
+	if !ok || field.PkgPath != "" {
+		return reflect.Value{}, false
+	}
+
+	for i, index := range field.Index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return reflect.Value{}, true
+			}
+			v = v.Elem()
+		}
+		v = v.Field(index)
+	}
+	return v, true
+}
+
+// Returns true when a field holds a non zero value, pointers are followed so a pointer to false isn't set.
+func isFieldSet(v reflect.Value) bool {
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return false
+		}
+		v = v.Elem()
+	}
+	return !v.IsZero()
+}
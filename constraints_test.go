@@ -0,0 +1,181 @@
+package remoteconfig
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ConstraintsSuite struct {
+	suite.Suite
+}
+
+func TestConstraintsSuite(t *testing.T) {
+	suite.Run(t, new(ConstraintsSuite))
+}
+
+func (s *ConstraintsSuite) check(value interface{}, rule string, param string) error {
+	return checkConstraint(reflect.ValueOf(value), fieldConstraint{Rule: rule, Param: param})
+}
+
+func (s *ConstraintsSuite) TestMinMaxNumbers() {
+	s.Nil(s.check(1, VALIDATION_RULE_MIN, "1"))
+	s.EqualError(s.check(0, VALIDATION_RULE_MIN, "1"), "must be at least 1")
+	s.Nil(s.check(int64(-5), VALIDATION_RULE_MAX, "-5"))
+	s.EqualError(s.check(int8(3), VALIDATION_RULE_MAX, "2"), "must be at most 2")
+	s.Nil(s.check(uint(3600), VALIDATION_RULE_MAX, "3600"))
+	s.EqualError(s.check(uint32(3601), VALIDATION_RULE_MAX, "3600"), "must be at most 3600")
+	s.Nil(s.check(0.5, VALIDATION_RULE_MIN, "0.1"))
+	s.EqualError(s.check(float32(0.05), VALIDATION_RULE_MIN, "0.1"), "must be at least 0.1")
+}
+
+func (s *ConstraintsSuite) TestMinMaxDuration() {
+	s.Nil(s.check(time.Minute, VALIDATION_RULE_MAX, "1h"))
+	s.EqualError(s.check(2*time.Hour, VALIDATION_RULE_MAX, "1h"), "must be at most 1h")
+	s.EqualError(s.check(time.Second, VALIDATION_RULE_MIN, "abc"), `invalid min rule parameter 'abc', with error, time: invalid duration "abc"`)
+}
+
+func (s *ConstraintsSuite) TestLengths() {
+	s.Nil(s.check("héllo", VALIDATION_RULE_LEN, "5"))
+	s.EqualError(s.check("abc", VALIDATION_RULE_LEN, "12"), "length must be 12")
+	s.EqualError(s.check("", VALIDATION_RULE_MIN, "1"), "length must be at least 1")
+	s.EqualError(s.check([]int{1, 2, 3}, VALIDATION_RULE_MAX, "2"), "length must be at most 2")
+	s.Nil(s.check([2]string{}, VALIDATION_RULE_LEN, "2"))
+	s.EqualError(s.check(map[string]int{}, VALIDATION_RULE_MIN, "1"), "length must be at least 1")
+	s.EqualError(s.check(5, VALIDATION_RULE_LEN, "1"), "len rule is not supported for type int")
+}
+
+func (s *ConstraintsSuite) TestOneOf() {
+	s.Nil(s.check("b", VALIDATION_RULE_ONE_OF, "a|b|c"))
+	s.EqualError(s.check("d", VALIDATION_RULE_ONE_OF, "a|b|c"), "must be one of a, b, c")
+	s.Nil(s.check(AWS_REGION_US_EAST_1, VALIDATION_RULE_ONE_OF, "us-east-1|us-west-2"))
+	s.Nil(s.check(3, VALIDATION_RULE_ONE_OF, "1|3|5"))
+}
+
+func (s *ConstraintsSuite) TestRegex() {
+	s.Nil(s.check("my-queue", VALIDATION_RULE_REGEX, "^[a-z-]+$"))
+	s.EqualError(s.check("My_Queue", VALIDATION_RULE_REGEX, "^[a-z-]+$"), "must match ^[a-z-]+$")
+	s.EqualError(s.check(1, VALIDATION_RULE_REGEX, "^1$"), "regex rule is not supported for type int")
+	s.Contains(s.check("a", VALIDATION_RULE_REGEX, "[").Error(), "invalid regex rule parameter '['")
+}
+
+func (s *ConstraintsSuite) TestPointers() {
+	str := "abc"
+	s.Nil(s.check(&str, VALIDATION_RULE_LEN, "3"))
+	s.EqualError(s.check(&str, VALIDATION_RULE_ONE_OF, "a|b"), "must be one of a, b")
+	s.Nil(s.check((*string)(nil), VALIDATION_RULE_LEN, "3"))
+}
+
+type CrossFieldConfig struct {
+	Provider *string
+	Location *string
+	Count    int
+	Enabled  bool
+}
+
+func (s *ConstraintsSuite) checkCrossField(c *CrossFieldConfig, field string, rule string, param string) error {
+	parent := reflect.ValueOf(c).Elem()
+	return checkCrossFieldConstraint(parent, parent.FieldByName(field), fieldConstraint{Rule: rule, Param: param}, "Storage")
+}
+
+type CrossFieldInnerConfig struct {
+	A *string `json:"a,omitempty" remoteconfig:"optional"`
+}
+
+type CrossFieldEmbeddedConfig struct {
+	*CrossFieldInnerConfig `remoteconfig:"optional"`
+	B                      *string `json:"b,omitempty" remoteconfig:"optional,required_with=A"`
+}
+
+func (s *ConstraintsSuite) TestRequiredWith() {
+	provider := "aws"
+	location := "us-east-1"
+
+	s.Nil(s.checkCrossField(&CrossFieldConfig{}, "Location", VALIDATION_RULE_REQUIRED_WITH, "Provider"))
+	s.Nil(s.checkCrossField(&CrossFieldConfig{Provider: &provider, Location: &location}, "Location", VALIDATION_RULE_REQUIRED_WITH, "Provider"))
+	s.EqualError(s.checkCrossField(&CrossFieldConfig{Provider: &provider}, "Location", VALIDATION_RULE_REQUIRED_WITH, "Provider"),
+		"required when Storage.Provider is set")
+
+	// A zero value doesn't count as set
+	s.Nil(s.checkCrossField(&CrossFieldConfig{Enabled: false}, "Count", VALIDATION_RULE_REQUIRED_WITH, "Enabled"))
+	s.EqualError(s.checkCrossField(&CrossFieldConfig{Enabled: true}, "Count", VALIDATION_RULE_REQUIRED_WITH, "Enabled"),
+		"required when Storage.Enabled is set")
+}
+
+func (s *ConstraintsSuite) TestExcludedWith() {
+	provider := "aws"
+	location := "us-east-1"
+
+	s.Nil(s.checkCrossField(&CrossFieldConfig{Location: &location}, "Location", VALIDATION_RULE_EXCLUDED_WITH, "Provider"))
+	s.Nil(s.checkCrossField(&CrossFieldConfig{Provider: &provider}, "Location", VALIDATION_RULE_EXCLUDED_WITH, "Provider"))
+	s.EqualError(s.checkCrossField(&CrossFieldConfig{Provider: &provider, Location: &location}, "Location", VALIDATION_RULE_EXCLUDED_WITH, "Provider"),
+		"must not be set when Storage.Provider is set")
+}
+
+func (s *ConstraintsSuite) TestRequiredIf() {
+	aws := "aws"
+	gcs := "gcs"
+	location := "us-east-1"
+
+	s.Nil(s.checkCrossField(&CrossFieldConfig{}, "Location", VALIDATION_RULE_REQUIRED_IF, "Provider:aws"))
+	s.Nil(s.checkCrossField(&CrossFieldConfig{Provider: &gcs}, "Location", VALIDATION_RULE_REQUIRED_IF, "Provider:aws"))
+	s.Nil(s.checkCrossField(&CrossFieldConfig{Provider: &aws, Location: &location}, "Location", VALIDATION_RULE_REQUIRED_IF, "Provider:aws"))
+	s.EqualError(s.checkCrossField(&CrossFieldConfig{Provider: &aws}, "Location", VALIDATION_RULE_REQUIRED_IF, "Provider:aws"),
+		"required when Storage.Provider is aws")
+	s.EqualError(s.checkCrossField(&CrossFieldConfig{Count: 2}, "Location", VALIDATION_RULE_REQUIRED_IF, "Count:2"),
+		"required when Storage.Count is 2")
+}
+
+func (s *ConstraintsSuite) TestCrossFieldNilEmbeddedPointer() {
+	// A sibling promoted through a nil embedded pointer isn't set
+	s.Nil(ReadJSONValidate(bytes.NewBufferString(`{"b": "x"}`), &CrossFieldEmbeddedConfig{}))
+	s.Nil(ReadJSONValidate(bytes.NewBufferString(`{"a": "x", "b": "y"}`), &CrossFieldEmbeddedConfig{}))
+
+	err := ReadJSONValidate(bytes.NewBufferString(`{"a": "x"}`), &CrossFieldEmbeddedConfig{})
+	s.True(errors.Is(err, ErrValidation))
+	s.Contains(err.Error(), "required when A is set")
+}
+
+type CrossFieldBaseConfig struct {
+	Port *int `json:"port,omitempty" remoteconfig:"optional,required_with=Host"`
+}
+
+type CrossFieldOuterConfig struct {
+	CrossFieldBaseConfig
+	Host *string `json:"host,omitempty" remoteconfig:"optional"`
+}
+
+func (s *ConstraintsSuite) TestCrossFieldPromotedField() {
+	// A rule on a field promoted from an embedded struct names siblings on the outer struct
+	s.Nil(ReadJSONValidate(bytes.NewBufferString(`{}`), &CrossFieldOuterConfig{}))
+	s.Nil(ReadJSONValidate(bytes.NewBufferString(`{"host": "localhost", "port": 80}`), &CrossFieldOuterConfig{}))
+
+	err := ReadJSONValidate(bytes.NewBufferString(`{"host": "localhost"}`), &CrossFieldOuterConfig{})
+	s.True(errors.Is(err, ErrValidation))
+	s.Contains(err.Error(), "required when Host is set")
+	s.Equal("port", err.(*ValidationError).JSONPath)
+}
+
+func (s *ConstraintsSuite) TestCrossFieldInvalidParam() {
+	s.EqualError(s.checkCrossField(&CrossFieldConfig{}, "Location", VALIDATION_RULE_REQUIRED_IF, "Provider"),
+		"invalid required_if rule parameter 'Provider', expected Field:value")
+	s.EqualError(s.checkCrossField(&CrossFieldConfig{}, "Location", VALIDATION_RULE_REQUIRED_WITH, "Missing"),
+		"required_with rule references unknown field Missing")
+}
+
+func (s *ConstraintsSuite) TestIsFieldSet() {
+	empty := ""
+	falseValue := false
+	trueValue := true
+
+	s.False(isFieldSet(reflect.ValueOf((*string)(nil))))
+	s.False(isFieldSet(reflect.ValueOf(&empty)))
+	s.False(isFieldSet(reflect.ValueOf(&falseValue)))
+	s.True(isFieldSet(reflect.ValueOf(&trueValue)))
+	s.False(isFieldSet(reflect.ValueOf(0)))
+	s.True(isFieldSet(reflect.ValueOf([]string{})))
+}
@@ -0,0 +1,89 @@
+package remoteconfig
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+)
+
+// Returned when a load runs past its context deadline or the client timeout.
+// Matches ErrTimeout with errors.Is, the underlying error is kept for context.DeadlineExceeded checks.
+type TimeoutError struct {
+	Err error
+}
+
+func (e *TimeoutError) Error() string {
+	return fmt.Sprintf("%s, with error, %s", ErrTimeout, e.Err)
+}
+
+func (e *TimeoutError) Unwrap() error {
+	return e.Err
+}
+
+func (e *TimeoutError) Is(target error) bool {
+	return target == ErrTimeout
+}
+
+func (e *TimeoutError) Timeout() bool {
+	return true
+}
+
+// Converts deadline and network timeout errors into a TimeoutError, other errors are returned as is.
+func wrapContextError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var timeoutErr *TimeoutError
+	if errors.As(err, &timeoutErr) {
+		return err
+	}
+
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) {
+		return &TimeoutError{Err: err}
+	}
+
+	return err
+}
+
+// Reader which stops returning data once its context is done.
+// Reads run on a separate goroutine so a reader that blocks still returns when the context is done,
+// the abandoned read carries on in the background until the underlying reader returns or is closed.
+type contextReader struct {
+	ctx    context.Context
+	reader io.Reader
+}
+
+type readResult struct {
+	n   int
+	err error
+}
+
+func (c *contextReader) Read(p []byte) (int, error) {
+	if err := c.ctx.Err(); err != nil {
+		return 0, err
+	}
+	// A context that's never done can't interrupt the read
+	if c.ctx.Done() == nil {
+		return c.reader.Read(p)
+	}
+
+	// The read fills its own buffer, p mustn't be written to once Read has returned
+	buf := make([]byte, len(p))
+	result := make(chan readResult, 1)
+	go func() {
+		n, err := c.reader.Read(buf)
+		result <- readResult{n: n, err: err}
+	}()
+
+	select {
+	case r := <-result:
+		copy(p, buf[:r.n])
+		return r.n, r.err
+	case <-c.ctx.Done():
+		return 0, c.ctx.Err()
+	}
+}
@@ -0,0 +1,95 @@
+package remoteconfig
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ContextSuite struct {
+	suite.Suite
+}
+
+func TestContextSuite(t *testing.T) {
+	suite.Run(t, new(ContextSuite))
+}
+
+type fakeTimeoutError struct{}
+
+func (fakeTimeoutError) Error() string   { return "i/o timeout" }
+func (fakeTimeoutError) Timeout() bool   { return true }
+func (fakeTimeoutError) Temporary() bool { return true }
+
+var _ net.Error = fakeTimeoutError{}
+
+func (s *ContextSuite) TestWrapContextErrorDeadlineExceeded() {
+	err := wrapContextError(context.DeadlineExceeded)
+	s.True(errors.Is(err, ErrTimeout))
+	s.True(errors.Is(err, context.DeadlineExceeded))
+	s.Equal("Config load timed out, with error, context deadline exceeded", err.Error())
+}
+
+func (s *ContextSuite) TestWrapContextErrorNetTimeout() {
+	err := wrapContextError(fakeTimeoutError{})
+	s.True(errors.Is(err, ErrTimeout))
+
+	var timeoutErr *TimeoutError
+	s.True(errors.As(err, &timeoutErr))
+	s.Equal(fakeTimeoutError{}, timeoutErr.Err)
+}
+
+func (s *ContextSuite) TestWrapContextErrorAlreadyWrapped() {
+	err := &TimeoutError{Err: context.DeadlineExceeded}
+	s.True(err == wrapContextError(err))
+}
+
+func (s *ContextSuite) TestWrapContextErrorOther() {
+	s.Nil(wrapContextError(nil))
+	s.Equal(context.Canceled, wrapContextError(context.Canceled))
+
+	err := errors.New("other")
+	s.Equal(err, wrapContextError(err))
+	s.False(errors.Is(wrapContextError(err), ErrTimeout))
+}
+
+func (s *ContextSuite) TestContextReader() {
+	ctx, cancel := context.WithCancel(context.Background())
+	reader := &contextReader{ctx: ctx, reader: bytes.NewBufferString("abcdef")}
+
+	buf := make([]byte, 3)
+	n, err := reader.Read(buf)
+	s.Nil(err)
+	s.Equal(3, n)
+
+	cancel()
+	n, err = reader.Read(buf)
+	s.Equal(0, n)
+	s.Equal(context.Canceled, err)
+}
+
+func (s *ContextSuite) TestContextReaderBlocked() {
+	pipeReader, pipeWriter := io.Pipe()
+	defer pipeWriter.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	errs := make(chan error, 1)
+	go func() {
+		errs <- ReadJSONValidateWithContext(ctx, pipeReader, &SampleConfig{})
+	}()
+
+	select {
+	case err := <-errs:
+		s.True(errors.Is(err, ErrTimeout))
+		s.True(errors.Is(err, context.DeadlineExceeded))
+	case <-time.After(2 * time.Second):
+		s.Fail("blocked read not stopped by the context deadline")
+	}
+}
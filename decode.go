@@ -0,0 +1,63 @@
+package remoteconfig
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// Decodes a document into the config struct, when strict is set keys that don't match a field are an error.
+// TOML documents are matched with the json struct tags, by way of a generic map.
+// Errors from the decoder are a *DecodeError, locating the problem in the document where the decoder allows.
+// Errors reading the document, i.e. a connection reset, are returned as is so they don't match ErrDecode.
+func decodeConfig(cfgReader io.Reader, format Format, configStruct interface{}, strict bool) error {
+	// The whole document is kept so errors can point at where in it they happened
+	data, err := ioutil.ReadAll(cfgReader)
+	if err != nil {
+		return err
+	}
+
+	switch format {
+	case FORMAT_YAML:
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(strict)
+		if err := decoder.Decode(configStruct); err != nil {
+			return newYAMLDecodeError(data, err)
+		}
+	case FORMAT_TOML:
+		if err := decodeTOML(data, configStruct, strict); err != nil {
+			return newTOMLDecodeError(data, configStruct, err)
+		}
+	default:
+		if err := decodeJSON(data, configStruct, strict); err != nil {
+			return newJSONDecodeError(data, configStruct, err)
+		}
+	}
+	return nil
+}
+
+func decodeJSON(data []byte, configStruct interface{}, strict bool) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	if strict {
+		decoder.DisallowUnknownFields()
+	}
+	return decoder.Decode(configStruct)
+}
+
+func decodeTOML(data []byte, configStruct interface{}, strict bool) error {
+	doc := map[string]interface{}{}
+	if _, err := toml.NewDecoder(bytes.NewReader(data)).Decode(&doc); err != nil {
+		return err
+	}
+
+	jsonData, err := json.Marshal(doc)
+	if err != nil {
+		return err
+	}
+
+	return decodeJSON(jsonData, configStruct, strict)
+}
@@ -0,0 +1,368 @@
+package remoteconfig
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// Longest snippet of the source included in a DecodeError, longer lines are cut down around the column.
+const decodeErrorSnippetLength = 60
+
+// Matches the line number in yaml.v3 error messages, i.e. "yaml: line 3: did not find expected key".
+var yamlErrorLineRegex = regexp.MustCompile(`line ([0-9]+)`)
+
+// Prefix of the error encoding/json returns for a key that doesn't match a field, when unknown fields are disallowed.
+const jsonUnknownFieldPrefix = "json: unknown field "
+
+// A config document that failed to decode, along with where in the document it failed.
+// Matches ErrDecode with errors.Is.
+type DecodeError struct {
+	// Format of the document, i.e. FORMAT_JSON
+	Format Format
+	// Line the error was found on, starting at 1. Zero when it isn't known.
+	Line int
+	// Column the error was found at in bytes, starting at 1. Zero when it isn't known.
+	Column int
+	// Path of the key being decoded using its names in the document, i.e. storage_config.provider.
+	// Empty at the top level of the document, or when it isn't known.
+	Path string
+	// Source line the error was found on, trimmed and cut down to a short length
+	Snippet string
+	// Error returned by the decoder
+	Err error
+}
+
+func (e *DecodeError) Error() string {
+	msg := fmt.Sprintf("Failed to decode %s, with error, %s", strings.ToUpper(string(e.Format)), e.Err.Error())
+	if e.Line > 0 {
+		msg += fmt.Sprintf(", at line %d", e.Line)
+		if e.Column > 0 {
+			msg += fmt.Sprintf(" column %d", e.Column)
+		}
+	}
+	if e.Path != "" {
+		msg += fmt.Sprintf(", in %s", e.Path)
+	}
+	if e.Snippet != "" {
+		msg += fmt.Sprintf(", near `%s`", e.Snippet)
+	}
+	return msg
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+func (e *DecodeError) Is(target error) bool {
+	return target == ErrDecode
+}
+
+// Builds a DecodeError for a JSON document, locating the error from the decoder's offset or the path it reports.
+func newJSONDecodeError(data []byte, configStruct interface{}, err error) *DecodeError {
+	decodeErr := &DecodeError{Format: FORMAT_JSON, Err: err}
+
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &syntaxErr):
+		// The offset is just past the character that couldn't be read
+		decodeErr.setOffset(data, int(syntaxErr.Offset)-1)
+		decodeErr.Path = jsonPathAtOffset(data, syntaxErr.Offset)
+	case err == io.ErrUnexpectedEOF:
+		decodeErr.setOffset(data, len(data))
+		decodeErr.Path = jsonPathAtOffset(data, int64(len(data)))
+	case errors.As(err, &typeErr):
+		// The offset is past the end of the value, so the key it's set by is located from the field's path instead
+		decodeErr.Path = typeErr.Field
+		if decodeErr.Path == "" {
+			decodeErr.Path = jsonPathAtOffset(data, typeErr.Offset)
+		}
+		offset, ok := jsonPathOffset(data, decodeErr.Path)
+		if !ok {
+			offset = typeErr.Offset - 1
+		}
+		decodeErr.setOffset(data, int(offset))
+	case strings.HasPrefix(err.Error(), jsonUnknownFieldPrefix):
+		path, offset := firstUnknownJSONKey(data, configStruct)
+		if path != "" {
+			decodeErr.setOffset(data, int(offset))
+			decodeErr.Path = path
+		}
+	}
+
+	return decodeErr
+}
+
+// Builds a DecodeError for a YAML document, locating the error from the line in the decoder's message.
+func newYAMLDecodeError(data []byte, err error) *DecodeError {
+	decodeErr := &DecodeError{Format: FORMAT_YAML, Err: err}
+
+	match := yamlErrorLineRegex.FindStringSubmatch(err.Error())
+	if match == nil {
+		return decodeErr
+	}
+	line, _ := strconv.Atoi(match[1])
+
+	decodeErr.Line = line
+	decodeErr.Snippet = sourceSnippet(data, line, 0)
+
+	// A document with a syntax error has no nodes to find the path from
+	var root yaml.Node
+	if yaml.Unmarshal(data, &root) == nil {
+		if path, column, ok := yamlPathAtLine(&root, "", line); ok {
+			decodeErr.Path = path
+			decodeErr.Column = column
+			decodeErr.Snippet = sourceSnippet(data, line, column)
+		}
+	}
+
+	return decodeErr
+}
+
+// Builds a DecodeError for a TOML document.
+// Only errors parsing the TOML itself have a position, the fields are decoded from a re-encoded copy of the document.
+func newTOMLDecodeError(data []byte, configStruct interface{}, err error) *DecodeError {
+	decodeErr := &DecodeError{Format: FORMAT_TOML, Err: err}
+
+	var parseErr toml.ParseError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &parseErr):
+		decodeErr.setOffset(data, parseErr.Position.Start)
+		decodeErr.Path = parseErr.LastKey
+	case errors.As(err, &typeErr):
+		decodeErr.Path = typeErr.Field
+	case strings.HasPrefix(err.Error(), jsonUnknownFieldPrefix):
+		if keys, findErr := findUnknownKeys(data, FORMAT_TOML, configStruct); findErr == nil && len(keys) > 0 {
+			decodeErr.Path = keys[0]
+		}
+	}
+
+	return decodeErr
+}
+
+// Sets the line, column and snippet from a byte offset into the document.
+func (e *DecodeError) setOffset(data []byte, offset int) {
+	if offset < 0 {
+		offset = 0
+	}
+	if offset > len(data) {
+		offset = len(data)
+	}
+
+	lineStart := bytes.LastIndexByte(data[:offset], '\n') + 1
+	e.Line = bytes.Count(data[:offset], []byte("\n")) + 1
+	e.Column = offset - lineStart + 1
+	e.Snippet = sourceSnippet(data, e.Line, e.Column)
+}
+
+// Returns a line of the document trimmed of white space, when it's too long it's cut down around the column.
+func sourceSnippet(data []byte, line int, column int) string {
+	lines := bytes.Split(data, []byte("\n"))
+	if line < 1 || line > len(lines) {
+		return ""
+	}
+	source := string(lines[line-1])
+
+	start := 0
+	if column > 0 && len(source) > decodeErrorSnippetLength {
+		start = column - 1 - decodeErrorSnippetLength/2
+		if start < 0 {
+			start = 0
+		}
+		if start > len(source)-decodeErrorSnippetLength {
+			start = len(source) - decodeErrorSnippetLength
+		}
+	}
+	// Don't start or end part way through a character
+	for start > 0 && !utf8.RuneStart(source[start]) {
+		start--
+	}
+	end := start + decodeErrorSnippetLength
+	if end >= len(source) {
+		end = len(source)
+	} else {
+		for end > start && !utf8.RuneStart(source[end]) {
+			end--
+		}
+	}
+
+	snippet := strings.TrimSpace(source[start:end])
+	if start > 0 {
+		snippet = "..." + snippet
+	}
+	if end < len(source) {
+		snippet += "..."
+	}
+	return snippet
+}
+
+// Calls fn with the path and byte offset of every key and array element in a JSON document, in document order.
+// Stops when fn returns false, or at the end of the document or the first syntax error.
+func walkJSON(data []byte, fn func(path string, offset int64) bool) {
+	type frame struct {
+		path      string
+		object    bool
+		expectKey bool
+		key       string
+		index     int
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	stack := []*frame{}
+	for {
+		offset := skipJSONSeparators(data, decoder.InputOffset())
+		token, err := decoder.Token()
+		if err != nil {
+			return
+		}
+
+		if delim, ok := token.(json.Delim); ok && (delim == '}' || delim == ']') {
+			stack = stack[:len(stack)-1]
+			if len(stack) == 0 {
+				return
+			}
+			continue
+		}
+
+		path := ""
+		if len(stack) > 0 {
+			top := stack[len(stack)-1]
+			switch {
+			case top.object && top.expectKey:
+				top.key, _ = token.(string)
+				top.expectKey = false
+				if !fn(joinFieldPath(top.path, top.key), offset) {
+					return
+				}
+				continue
+			case top.object:
+				top.expectKey = true
+				path = joinFieldPath(top.path, top.key)
+			default:
+				path = fmt.Sprintf("%s[%d]", top.path, top.index)
+				top.index++
+				if !fn(path, offset) {
+					return
+				}
+			}
+		}
+
+		switch token {
+		case json.Delim('{'):
+			stack = append(stack, &frame{path: path, object: true, expectKey: true})
+		case json.Delim('['):
+			stack = append(stack, &frame{path: path})
+		default:
+			if len(stack) == 0 {
+				return
+			}
+		}
+	}
+}
+
+// Skips the white space, commas and colons the decoder's offset can point at before a token.
+func skipJSONSeparators(data []byte, offset int64) int64 {
+	for offset < int64(len(data)) {
+		switch data[offset] {
+		case ' ', '\t', '\r', '\n', ',', ':':
+			offset++
+		default:
+			return offset
+		}
+	}
+	return offset
+}
+
+// Returns the path of the last key or element in a JSON document that starts before the offset.
+func jsonPathAtOffset(data []byte, offset int64) string {
+	path := ""
+	walkJSON(data, func(p string, o int64) bool {
+		if o >= offset {
+			return false
+		}
+		path = p
+		return true
+	})
+	return path
+}
+
+// Returns the offset of a key or element in a JSON document from its path.
+func jsonPathOffset(data []byte, path string) (int64, bool) {
+	offset, found := int64(0), false
+	walkJSON(data, func(p string, o int64) bool {
+		if p == path {
+			offset, found = o, true
+			return false
+		}
+		return true
+	})
+	return offset, found
+}
+
+// Returns the path and offset of the first key in a JSON document that doesn't match a field of the config struct.
+func firstUnknownJSONKey(data []byte, configStruct interface{}) (string, int64) {
+	keys, err := findUnknownKeys(data, FORMAT_JSON, configStruct)
+	if err != nil || len(keys) == 0 {
+		return "", 0
+	}
+
+	unknown := map[string]bool{}
+	for _, key := range keys {
+		unknown[key] = true
+	}
+
+	path, offset := "", int64(0)
+	walkJSON(data, func(p string, o int64) bool {
+		if unknown[p] {
+			path, offset = p, o
+			return false
+		}
+		return true
+	})
+	return path, offset
+}
+
+// Returns the path and column of the first key or element on a line of a YAML document.
+func yamlPathAtLine(node *yaml.Node, path string, line int) (string, int, bool) {
+	switch node.Kind {
+	case yaml.DocumentNode:
+		for _, child := range node.Content {
+			if p, column, ok := yamlPathAtLine(child, path, line); ok {
+				return p, column, true
+			}
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			keyPath := joinFieldPath(path, key.Value)
+			if key.Line == line {
+				return keyPath, key.Column, true
+			}
+			if p, column, ok := yamlPathAtLine(value, keyPath, line); ok {
+				return p, column, true
+			}
+		}
+	case yaml.SequenceNode:
+		for i, item := range node.Content {
+			itemPath := fmt.Sprintf("%s[%d]", path, i)
+			if item.Line == line && item.Kind == yaml.ScalarNode {
+				return itemPath, item.Column, true
+			}
+			if p, column, ok := yamlPathAtLine(item, itemPath, line); ok {
+				return p, column, true
+			}
+		}
+	}
+	return "", 0, false
+}
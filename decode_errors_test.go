@@ -0,0 +1,192 @@
+package remoteconfig
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type DecodeErrorsSuite struct {
+	suite.Suite
+}
+
+func TestDecodeErrorsSuite(t *testing.T) {
+	suite.Run(t, new(DecodeErrorsSuite))
+}
+
+func (s *DecodeErrorsSuite) decodeError(doc string, format Format, strict bool) *DecodeError {
+	err := decodeConfig(bytes.NewBufferString(doc), format, &SampleConfig{}, strict)
+	s.NotNil(err)
+
+	var decodeErr *DecodeError
+	s.True(errors.As(err, &decodeErr))
+	return decodeErr
+}
+
+func (s *DecodeErrorsSuite) TestJSONSyntaxError() {
+	doc := strings.Replace(validConfigJSON, `"testTable"`, `"testTable",}`, 1)
+
+	decodeErr := s.decodeError(doc, FORMAT_JSON, false)
+	s.Equal(FORMAT_JSON, decodeErr.Format)
+	s.Equal(19, decodeErr.Line)
+	s.Equal(31, decodeErr.Column)
+	s.Equal("dynamodb_table.table_name", decodeErr.Path)
+	s.Equal(`"table_name" : "testTable",}`, decodeErr.Snippet)
+
+	var syntaxErr *json.SyntaxError
+	s.True(errors.As(decodeErr, &syntaxErr))
+	s.EqualError(decodeErr, "Failed to decode JSON, with error, invalid character '}' looking for beginning of object key string, at line 19 column 31, in dynamodb_table.table_name, near `\"table_name\" : \"testTable\",}`")
+}
+
+func (s *DecodeErrorsSuite) TestJSONSyntaxErrorInArray() {
+	doc := strings.Replace(validConfigJSON, `"location" : "us-east-1"`, `"location" : "us-east-1",,`, 1)
+
+	decodeErr := s.decodeError(doc, FORMAT_JSON, false)
+	s.Equal("storage_config_slice[1].location", decodeErr.Path)
+	s.Equal(`"location" : "us-east-1",,`, decodeErr.Snippet)
+}
+
+func (s *DecodeErrorsSuite) TestJSONUnexpectedEnd() {
+	decodeErr := s.decodeError("{\n  \"str\": \"testStr\",\n  \"str_slice\": [", FORMAT_JSON, false)
+	s.Equal(io.ErrUnexpectedEOF, decodeErr.Err)
+	s.Equal(3, decodeErr.Line)
+	s.Equal(17, decodeErr.Column)
+	s.Equal("str_slice", decodeErr.Path)
+	s.Equal(`"str_slice": [`, decodeErr.Snippet)
+}
+
+func (s *DecodeErrorsSuite) TestJSONTypeError() {
+	doc := strings.Replace(validConfigJSON, `"embedded_int": 123`, `"embedded_int": "123"`, 1)
+
+	decodeErr := s.decodeError(doc, FORMAT_JSON, false)
+	s.Equal(4, decodeErr.Line)
+	s.Equal(3, decodeErr.Column)
+	s.Equal("embedded_int", decodeErr.Path)
+	s.Equal(`"embedded_int": "123",`, decodeErr.Snippet)
+
+	var typeErr *json.UnmarshalTypeError
+	s.True(errors.As(decodeErr, &typeErr))
+}
+
+func (s *DecodeErrorsSuite) TestJSONUnknownField() {
+	doc := strings.Replace(validConfigJSON, `"table_name"`, `"tabel_name"`, 1)
+
+	decodeErr := s.decodeError(doc, FORMAT_JSON, true)
+	s.Equal(19, decodeErr.Line)
+	s.Equal(4, decodeErr.Column)
+	s.Equal("dynamodb_table.tabel_name", decodeErr.Path)
+}
+
+func (s *DecodeErrorsSuite) TestJSONUnmarshalerError() {
+	doc := strings.Replace(validConfigJSON, `"provider": "aws"`, `"provider": "gcs"`, 1)
+
+	decodeErr := s.decodeError(doc, FORMAT_JSON, false)
+	s.EqualError(decodeErr, "Failed to decode JSON, with error, Invalid storage provider")
+}
+
+func (s *DecodeErrorsSuite) TestYAMLSyntaxError() {
+	decodeErr := s.decodeError("str: testStr\nstr_pointer: testStr\n  str_slice: hello\n", FORMAT_YAML, false)
+	s.Equal(FORMAT_YAML, decodeErr.Format)
+	s.Equal(3, decodeErr.Line)
+	s.Equal(0, decodeErr.Column)
+	s.Equal("", decodeErr.Path)
+	s.Equal("str_slice: hello", decodeErr.Snippet)
+	s.EqualError(decodeErr, "Failed to decode YAML, with error, yaml: line 3: mapping values are not allowed in this context, at line 3, near `str_slice: hello`")
+}
+
+func (s *DecodeErrorsSuite) TestYAMLTypeError() {
+	doc := strings.Replace(validConfigYAML, "embedded_int: 123", "embedded_int: abc", 1)
+
+	decodeErr := s.decodeError(doc, FORMAT_YAML, false)
+	s.Equal(3, decodeErr.Line)
+	s.Equal(1, decodeErr.Column)
+	s.Equal("embedded_int", decodeErr.Path)
+	s.Equal("embedded_int: abc", decodeErr.Snippet)
+}
+
+func (s *DecodeErrorsSuite) TestYAMLUnknownField() {
+	decodeErr := s.decodeError("str: testStr\nstorage_config_slice:\n  - provider: aws\n  - provder: aws\n", FORMAT_YAML, true)
+	s.Equal(4, decodeErr.Line)
+	s.Equal(5, decodeErr.Column)
+	s.Equal("storage_config_slice[1].provder", decodeErr.Path)
+	s.Equal("- provder: aws", decodeErr.Snippet)
+}
+
+func (s *DecodeErrorsSuite) TestTOMLParseError() {
+	decodeErr := s.decodeError("str = \"testStr\"\nstr_slice = [ \"hello\" \n", FORMAT_TOML, false)
+	s.Equal(FORMAT_TOML, decodeErr.Format)
+	s.Equal(2, decodeErr.Line)
+	s.Equal("str_slice", decodeErr.Path)
+	s.Equal(`str_slice = [ "hello"`, decodeErr.Snippet)
+}
+
+func (s *DecodeErrorsSuite) TestTOMLTypeError() {
+	doc := strings.Replace(validConfigTOML, "embedded_int = 123", `embedded_int = "abc"`, 1)
+
+	decodeErr := s.decodeError(doc, FORMAT_TOML, false)
+	s.Equal(0, decodeErr.Line)
+	s.Equal("embedded_int", decodeErr.Path)
+}
+
+func (s *DecodeErrorsSuite) TestReadError() {
+	// A failure reading the document isn't a problem with the document itself
+	err := decodeConfig(&errorReader{}, FORMAT_JSON, &SampleConfig{}, false)
+	s.EqualError(err, "read failed")
+	s.False(errors.Is(err, ErrDecode))
+
+	err = ReadJSONValidate(&errorReader{}, &SampleConfig{})
+	s.EqualError(err, "read failed")
+	s.False(errors.Is(err, ErrDecode))
+}
+
+func (s *DecodeErrorsSuite) TestReadJSONValidateDecodeError() {
+	err := ReadJSONValidate(bytes.NewBufferString("{\n  \"str\": testStr\n}"), &SampleConfig{})
+
+	var decodeErr *DecodeError
+	s.True(errors.As(err, &decodeErr))
+	s.Equal(2, decodeErr.Line)
+	// encoding/json reads testStr as far as the e, expecting true
+	s.Equal(11, decodeErr.Column)
+	s.Equal("str", decodeErr.Path)
+	s.Equal(`"str": testStr`, decodeErr.Snippet)
+}
+
+func (s *DecodeErrorsSuite) TestSourceSnippet() {
+	data := []byte("first\n  second line  \nthird")
+	s.Equal("first", sourceSnippet(data, 1, 1))
+	s.Equal("second line", sourceSnippet(data, 2, 0))
+	s.Equal("", sourceSnippet(data, 4, 1))
+	s.Equal("", sourceSnippet(data, 0, 1))
+
+	long := []byte(strings.Repeat("a", 50) + "X" + strings.Repeat("b", 50))
+	s.Equal("..."+strings.Repeat("a", 30)+"X"+strings.Repeat("b", 29)+"...", sourceSnippet(long, 1, 51))
+	s.Equal(strings.Repeat("a", 50)+"X"+strings.Repeat("b", 9)+"...", sourceSnippet(long, 1, 1))
+	s.Equal("..."+strings.Repeat("a", 9)+"X"+strings.Repeat("b", 50), sourceSnippet(long, 1, 101))
+}
+
+func (s *DecodeErrorsSuite) TestWalkJSON() {
+	paths := []string{}
+	walkJSON([]byte(`{"a": {"b": [1, {"c": true}]}, "d": "e"}`), func(path string, offset int64) bool {
+		paths = append(paths, path)
+		return true
+	})
+	s.Equal([]string{"a", "a.b", "a.b[0]", "a.b[1]", "a.b[1].c", "d"}, paths)
+
+	offset, ok := jsonPathOffset([]byte(`{"a": 1, "d": "e"}`), "d")
+	s.True(ok)
+	s.EqualValues(9, offset)
+
+	_, ok = jsonPathOffset([]byte(`{"a": 1}`), "d")
+	s.False(ok)
+}
+
+type errorReader struct{}
+
+func (r *errorReader) Read(p []byte) (int, error) {
+	return 0, errors.New("read failed")
+}
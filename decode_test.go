@@ -0,0 +1,110 @@
+package remoteconfig
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+var validConfigTOML = `
+embedded_string = "abc"
+embedded_int = 123
+str = "testStr"
+str_pointer = "testStr"
+str_slice = [ "hello" ]
+
+[sqs_client]
+region = "us-east-1"
+endpoint = "http://localhost:3000/sqs"
+
+[sqs_queue]
+region = "us-east-1"
+aws_account_id = "345833302425"
+queue_name = "testQueue"
+
+[dynamodb_client]
+region = "us-east-1"
+endpoint = "http://localhost:8000/dynamodb"
+
+[dynamodb_table]
+table_name = "testTable"
+
+[storage_config]
+provider = "aws"
+location = "us-west-2"
+
+[[storage_config_slice]]
+provider = "aws"
+location = "us-west-2"
+
+[[storage_config_slice]]
+provider = "aws"
+location = "us-east-1"
+
+[storage_config_map.one]
+provider = "aws"
+location = "us-west-2"
+
+[map_str_str]
+key = "value"
+`
+
+type DecodeSuite struct {
+	suite.Suite
+}
+
+func TestDecodeSuite(t *testing.T) {
+	suite.Run(t, new(DecodeSuite))
+}
+
+func (s *DecodeSuite) TestDecodeConfigFormatsMatch() {
+	jsonConfig := &SampleConfig{}
+	s.Nil(decodeConfig(bytes.NewBufferString(validConfigJSON), FORMAT_JSON, jsonConfig, false))
+
+	yamlConfig := &SampleConfig{}
+	s.Nil(decodeConfig(bytes.NewBufferString(validConfigYAML), FORMAT_YAML, yamlConfig, false))
+
+	tomlConfig := &SampleConfig{}
+	s.Nil(decodeConfig(bytes.NewBufferString(validConfigTOML), FORMAT_TOML, tomlConfig, false))
+
+	s.Equal(jsonConfig, yamlConfig)
+	s.Equal(jsonConfig, tomlConfig)
+}
+
+func (s *DecodeSuite) TestDecodeConfigTOMLErrorSyntax() {
+	err := decodeConfig(bytes.NewBufferString("str = "), FORMAT_TOML, &SampleConfig{}, false)
+	s.NotNil(err)
+	s.Contains(err.Error(), "Failed to decode TOML, with error, ")
+}
+
+func (s *DecodeSuite) TestDecodeConfigTOMLErrorUnmarshalText() {
+	err := decodeConfig(bytes.NewBufferString("[storage_config]\nprovider = \"gcs\"\n"), FORMAT_TOML, &SampleConfig{}, false)
+	s.NotNil(err)
+	s.Equal("Failed to decode TOML, with error, Invalid storage provider", err.Error())
+}
+
+func (s *DecodeSuite) TestDecodeConfigJSONError() {
+	err := decodeConfig(bytes.NewBufferString("Not JSON"), FORMAT_JSON, &SampleConfig{}, false)
+	s.EqualError(err, "Failed to decode JSON, with error, invalid character 'N' looking for beginning of value, at line 1 column 1, near `Not JSON`")
+}
+
+func (s *DecodeSuite) TestDecodeConfigStrict() {
+	s.Nil(decodeConfig(bytes.NewBufferString(validConfigJSON), FORMAT_JSON, &SampleConfig{}, true))
+	s.Nil(decodeConfig(bytes.NewBufferString(validConfigYAML), FORMAT_YAML, &SampleConfig{}, true))
+	s.Nil(decodeConfig(bytes.NewBufferString(validConfigTOML), FORMAT_TOML, &SampleConfig{}, true))
+
+	err := decodeConfig(bytes.NewBufferString(`{"dynamodb_table": {"tabel_name": "testTable"}}`), FORMAT_JSON, &SampleConfig{}, true)
+	s.EqualError(err, "Failed to decode JSON, with error, json: unknown field \"tabel_name\", at line 1 column 21, in dynamodb_table.tabel_name, near `{\"dynamodb_table\": {\"tabel_name\": \"testTable\"}}`")
+
+	err = decodeConfig(bytes.NewBufferString("dynamodb_table:\n  tabel_name: testTable\n"), FORMAT_YAML, &SampleConfig{}, true)
+	s.NotNil(err)
+	s.Contains(err.Error(), "Failed to decode YAML, with error, ")
+	s.Contains(err.Error(), "line 2: field tabel_name not found")
+
+	err = decodeConfig(bytes.NewBufferString("[dynamodb_table]\ntabel_name = \"testTable\"\n"), FORMAT_TOML, &SampleConfig{}, true)
+	s.EqualError(err, `Failed to decode TOML, with error, json: unknown field "tabel_name", in dynamodb_table.tabel_name`)
+
+	// Unknown keys are ignored when not strict
+	s.Nil(decodeConfig(bytes.NewBufferString(`{"dynamodb_table": {"tabel_name": "testTable"}}`), FORMAT_JSON, &SampleConfig{}, false))
+}
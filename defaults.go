@@ -0,0 +1,150 @@
+package remoteconfig
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+var (
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	durationType        = reflect.TypeOf(time.Duration(0))
+)
+
+// Fills nil or zero fields that have a `remoteconfig:"default=..."` tag.
+// Runs after decoding and before validation, nested structs, slices and maps of structs are walked too.
+// Use pointer fields to tell an explicit zero value apart from an unset one.
+func applyDefaultsWithReflection(c interface{}) error {
+	return applyDefaults(reflect.ValueOf(c), "")
+}
+
+func applyDefaults(v reflect.Value, path string) error {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return nil
+		}
+		return applyDefaults(v.Elem(), path)
+
+	case reflect.Struct:
+		for _, field := range getStructPlan(v.Type()).fields {
+			valueField := v.Field(field.index)
+
+			// Fields of embedded structs are promoted, so they keep the parent path
+			fieldPath := path
+			if !field.anonymous {
+				fieldPath = joinFieldPath(path, field.name)
+			}
+
+			tag := field.tag
+			if tag.HasDefault && valueField.CanSet() && valueField.IsZero() {
+				if err := setFromString(valueField, tag.Default); err != nil {
+					return fmt.Errorf("Field: %s, invalid default value '%s', with error, %w", fieldPath, tag.Default, err)
+				}
+			}
+
+			if err := applyDefaults(valueField, fieldPath); err != nil {
+				return err
+			}
+		}
+
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if err := applyDefaults(v.Index(i), fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+
+	case reflect.Map:
+		for _, key := range v.MapKeys() {
+			mapValue := v.MapIndex(key)
+			keyPath := fmt.Sprintf("%s[%q]", path, fmt.Sprint(key.Interface()))
+
+			// Map values aren't addressable, so value structs are defaulted on a copy which is stored back
+			if mapValue.Kind() == reflect.Struct {
+				copied := reflect.New(mapValue.Type()).Elem()
+				copied.Set(mapValue)
+				if err := applyDefaults(copied, keyPath); err != nil {
+					return err
+				}
+				v.SetMapIndex(key, copied)
+				continue
+			}
+
+			if err := applyDefaults(mapValue, keyPath); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// Sets a value from its string form.
+// Supports pointers, TextUnmarshaler types (i.e. AWSRegion), durations, strings, bools and numbers.
+func setFromString(v reflect.Value, s string) error {
+	if v.Kind() == reflect.Ptr {
+		ptr := reflect.New(v.Type().Elem())
+		if err := setFromString(ptr.Elem(), s); err != nil {
+			return err
+		}
+		v.Set(ptr)
+		return nil
+	}
+
+	if reflect.PtrTo(v.Type()).Implements(textUnmarshalerType) {
+		return v.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(s))
+	}
+
+	if v.Type() == durationType {
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(d))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	default:
+		return fmt.Errorf("Unsupported default type %s", v.Type())
+	}
+
+	return nil
+}
+
+// Joins a parent field path and a field name, i.e. SQSQueue.Region
+func joinFieldPath(path string, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
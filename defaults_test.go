@@ -0,0 +1,141 @@
+package remoteconfig
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type DefaultsEmbeddedConfig struct {
+	EmbeddedStr *string `json:"embedded_str,omitempty" remoteconfig:"default=embedded"`
+}
+
+type DefaultsSubConfig struct {
+	Count *int `json:"count,omitempty" remoteconfig:"default=3"`
+}
+
+type DefaultsConfig struct {
+	DefaultsEmbeddedConfig
+	Int         int                          `json:"int,omitempty" remoteconfig:"default=-5"`
+	IntPointer  *int64                       `json:"int_pointer,omitempty" remoteconfig:"default=60"`
+	Uint        *uint                        `json:"uint,omitempty" remoteconfig:"default=7"`
+	Float       float64                      `json:"float,omitempty" remoteconfig:"default=1.5"`
+	Str         string                       `json:"str,omitempty" remoteconfig:"default=hello"`
+	Bool        *bool                        `json:"bool,omitempty" remoteconfig:"default=true"`
+	Duration    time.Duration                `json:"duration,omitempty" remoteconfig:"default=1m30s"`
+	DurationPtr *time.Duration               `json:"duration_ptr,omitempty" remoteconfig:"default=5s"`
+	Region      AWSRegion                    `json:"region,omitempty" remoteconfig:"default=us-west-2"`
+	Provider    *StorageProvider             `json:"provider,omitempty" remoteconfig:"default=aws"`
+	Sub         *DefaultsSubConfig           `json:"sub,omitempty"`
+	SubSlice    []*DefaultsSubConfig         `json:"sub_slice,omitempty"`
+	SubMap      map[string]DefaultsSubConfig `json:"sub_map,omitempty"`
+	NoDefault   *string                      `json:"no_default,omitempty" remoteconfig:"optional"`
+}
+
+type DefaultsSuite struct {
+	suite.Suite
+}
+
+func TestDefaultsSuite(t *testing.T) {
+	suite.Run(t, new(DefaultsSuite))
+}
+
+func (s *DefaultsSuite) TestApplyDefaultsWithReflection() {
+	c := &DefaultsConfig{
+		Sub:      &DefaultsSubConfig{},
+		SubSlice: []*DefaultsSubConfig{{}, nil},
+		SubMap:   map[string]DefaultsSubConfig{"one": {}},
+	}
+
+	err := applyDefaultsWithReflection(c)
+	s.Nil(err)
+	s.Equal("embedded", *c.EmbeddedStr)
+	s.Equal(-5, c.Int)
+	s.EqualValues(60, *c.IntPointer)
+	s.EqualValues(7, *c.Uint)
+	s.Equal(1.5, c.Float)
+	s.Equal("hello", c.Str)
+	s.True(*c.Bool)
+	s.Equal(90*time.Second, c.Duration)
+	s.Equal(5*time.Second, *c.DurationPtr)
+	s.Equal(AWS_REGION_US_WEST_2, c.Region)
+	s.Equal(STORAGE_PROVIDER_AWS, *c.Provider)
+	s.Equal(3, *c.Sub.Count)
+	s.Equal(3, *c.SubSlice[0].Count)
+	s.Nil(c.SubSlice[1])
+	s.Equal(3, *c.SubMap["one"].Count)
+	s.Nil(c.NoDefault)
+}
+
+func (s *DefaultsSuite) TestApplyDefaultsWithReflectionKeepsSetValues() {
+	i := int64(10)
+	f := false
+	c := &DefaultsConfig{
+		Int:        1,
+		IntPointer: &i,
+		Bool:       &f,
+		Region:     AWS_REGION_EU_WEST_1,
+	}
+
+	err := applyDefaultsWithReflection(c)
+	s.Nil(err)
+	s.Equal(1, c.Int)
+	s.EqualValues(10, *c.IntPointer)
+	s.False(*c.Bool)
+	s.Equal(AWS_REGION_EU_WEST_1, c.Region)
+	s.Nil(c.Sub)
+}
+
+func (s *DefaultsSuite) TestApplyDefaultsWithReflectionErrorInvalidDefault() {
+	c := &struct {
+		Region *AWSRegion `remoteconfig:"default=mars-1"`
+	}{}
+
+	err := applyDefaultsWithReflection(c)
+	s.EqualError(err, "Field: Region, invalid default value 'mars-1', with error, Region is invalid")
+	s.True(errors.Is(err, ErrAWSRegionInvalid))
+}
+
+func (s *DefaultsSuite) TestApplyDefaultsWithReflectionErrorNestedPath() {
+	c := &struct {
+		Subs []*struct {
+			Count int `remoteconfig:"default=many"`
+		}
+	}{}
+	c.Subs = append(c.Subs, &struct {
+		Count int `remoteconfig:"default=many"`
+	}{})
+
+	err := applyDefaultsWithReflection(c)
+	s.NotNil(err)
+	s.Contains(err.Error(), "Field: Subs[0].Count, invalid default value 'many'")
+}
+
+func (s *DefaultsSuite) TestApplyDefaultsWithReflectionErrorUnsupportedType() {
+	c := &struct {
+		Strs []string `remoteconfig:"default=a"`
+	}{}
+
+	err := applyDefaultsWithReflection(c)
+	s.EqualError(err, "Field: Strs, invalid default value 'a', with error, Unsupported default type []string")
+}
+
+func (s *DefaultsSuite) TestReadJSONValidateAppliesDefaults() {
+	c := &S3Config{}
+	err := ReadJSONValidate(bytes.NewBufferString(`{"bucket": "bucket", "region": "us-west-2"}`), c)
+	s.Nil(err)
+	s.EqualValues(S3_CONFIG_DEFAULT_EXPIRY, *c.Expiry)
+	s.Nil(c.Endpoint)
+}
+
+func (s *DefaultsSuite) TestReadJSONValidateDefaultsBeforeValidation() {
+	c := &struct {
+		Region *AWSRegion `json:"region" remoteconfig:"default=us-east-1"`
+	}{}
+	err := ReadJSONValidate(bytes.NewBufferString(`{}`), c)
+	s.Nil(err)
+	s.Equal(AWS_REGION_US_EAST_1, *c.Region)
+}
@@ -0,0 +1,108 @@
+package remoteconfig
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Returns the sorted paths of the fields that differ between two configs of the same type, using their json names,
+// i.e. sqs_queue.queue_name or storage_config_map.one.provider.
+// A slice that changed length, or a pointer that was set or cleared, is reported as a whole.
+func diffConfig(old interface{}, new interface{}) []string {
+	changes := []string{}
+	diffValues(reflect.ValueOf(old), reflect.ValueOf(new), "", &changes)
+	sort.Strings(changes)
+	return changes
+}
+
+func diffValues(old reflect.Value, new reflect.Value, path string, changes *[]string) {
+	if !old.IsValid() || !new.IsValid() {
+		if old.IsValid() != new.IsValid() {
+			*changes = append(*changes, path)
+		}
+		return
+	}
+	if old.Type() != new.Type() {
+		*changes = append(*changes, path)
+		return
+	}
+
+	// Types that decode themselves, i.e. time.Time, are compared as a whole
+	t := old.Type()
+	if t.Kind() != reflect.Ptr && t.Kind() != reflect.Interface && (reflect.PtrTo(t).Implements(jsonUnmarshalerType) || reflect.PtrTo(t).Implements(textUnmarshalerType)) {
+		if !reflect.DeepEqual(old.Interface(), new.Interface()) {
+			*changes = append(*changes, path)
+		}
+		return
+	}
+
+	switch old.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if old.IsNil() || new.IsNil() {
+			if old.IsNil() != new.IsNil() {
+				*changes = append(*changes, path)
+			}
+			return
+		}
+		diffValues(old.Elem(), new.Elem(), path, changes)
+
+	case reflect.Struct:
+		for _, field := range getStructPlan(t).fields {
+			oldField, newField := old.Field(field.index), new.Field(field.index)
+			if field.embedded {
+				diffValues(oldField, newField, path, changes)
+				continue
+			}
+			if !oldField.CanInterface() {
+				continue
+			}
+			diffValues(oldField, newField, joinFieldPath(path, field.jsonName), changes)
+		}
+
+	case reflect.Slice, reflect.Array:
+		if old.Len() != new.Len() || old.Kind() == reflect.Slice && old.IsNil() != new.IsNil() {
+			*changes = append(*changes, path)
+			return
+		}
+		for i := 0; i < old.Len(); i++ {
+			diffValues(old.Index(i), new.Index(i), fmt.Sprintf("%s[%d]", path, i), changes)
+		}
+
+	case reflect.Map:
+		if old.IsNil() != new.IsNil() {
+			*changes = append(*changes, path)
+			return
+		}
+		keys := old.MapKeys()
+		for _, key := range new.MapKeys() {
+			if !old.MapIndex(key).IsValid() {
+				keys = append(keys, key)
+			}
+		}
+		for _, key := range keys {
+			keyPath := joinFieldPath(path, fmt.Sprint(key.Interface()))
+			diffValues(old.MapIndex(key), new.MapIndex(key), keyPath, changes)
+		}
+
+	default:
+		if !reflect.DeepEqual(old.Interface(), new.Interface()) {
+			*changes = append(*changes, path)
+		}
+	}
+}
+
+// Returns true when a change affects the subtree at the path, that's a change within it or to one of its parents.
+// An empty path is the whole config.
+func pathAffected(change string, path string) bool {
+	return path == "" || isSubPath(change, path) || isSubPath(path, change)
+}
+
+// Returns true when the path is the parent path, or within it.
+func isSubPath(path string, parent string) bool {
+	if parent == "" || path == parent {
+		return true
+	}
+	return strings.HasPrefix(path, parent) && (path[len(parent)] == '.' || path[len(parent)] == '[')
+}
@@ -0,0 +1,111 @@
+package remoteconfig
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type DiffSuite struct {
+	suite.Suite
+}
+
+func TestDiffSuite(t *testing.T) {
+	suite.Run(t, new(DiffSuite))
+}
+
+func (s *DiffSuite) decode(doc string) *SampleConfig {
+	c := &SampleConfig{}
+	s.Nil(ReadJSONValidate(bytes.NewBufferString(doc), c))
+	return c
+}
+
+func (s *DiffSuite) TestDiffConfigUnchanged() {
+	s.Equal([]string{}, diffConfig(s.decode(validConfigJSON), s.decode(validConfigJSON)))
+}
+
+func (s *DiffSuite) TestDiffConfig() {
+	doc := strings.Replace(validConfigJSON, `"testQueue"`, `"otherQueue"`, 1)
+	doc = strings.Replace(doc, `"embedded_int": 123`, `"embedded_int": 456`, 1)
+	doc = strings.Replace(doc, `"us-east-1"`, `"us-west-2"`, 1)
+
+	s.Equal([]string{
+		"embedded_int",
+		"sqs_client.region",
+		"sqs_queue.queue_name",
+	}, diffConfig(s.decode(validConfigJSON), s.decode(doc)))
+}
+
+func (s *DiffSuite) TestDiffConfigPointers() {
+	region := AWS_REGION_US_EAST_1
+	old := &SampleConfig{SQSQueue: &SQSQueueConfig{Region: &region}}
+
+	s.Equal([]string{"sqs_queue"}, diffConfig(old, &SampleConfig{}))
+	s.Equal([]string{"sqs_queue.region"}, diffConfig(old, &SampleConfig{SQSQueue: &SQSQueueConfig{}}))
+	s.Equal([]string{"sqs_client"}, diffConfig(&SampleConfig{}, &SampleConfig{SQSClient: &SQSClientConfig{}}))
+}
+
+func (s *DiffSuite) TestDiffConfigSlicesAndMaps() {
+	one, two := "one", "two"
+	old := &struct {
+		Strs []string               `json:"strs"`
+		Ptrs []*string              `json:"ptrs"`
+		Map  map[string]string      `json:"map"`
+		Any  map[string]interface{} `json:"any"`
+	}{
+		Strs: []string{"a", "b"},
+		Ptrs: []*string{&one},
+		Map:  map[string]string{"a": "1", "b": "2"},
+		Any:  map[string]interface{}{"n": 1.0, "s": []interface{}{"x"}},
+	}
+	new := &struct {
+		Strs []string               `json:"strs"`
+		Ptrs []*string              `json:"ptrs"`
+		Map  map[string]string      `json:"map"`
+		Any  map[string]interface{} `json:"any"`
+	}{
+		Strs: []string{"a", "b", "c"},
+		Ptrs: []*string{&two},
+		Map:  map[string]string{"a": "1", "b": "3", "c": "4"},
+		Any:  map[string]interface{}{"n": "1", "s": []interface{}{"y"}},
+	}
+
+	s.Equal([]string{
+		"any.n",
+		"any.s[0]",
+		"map.b",
+		"map.c",
+		"ptrs[0]",
+		"strs",
+	}, diffConfig(old, new))
+}
+
+func (s *DiffSuite) TestDiffConfigEmbeddedAndUnmarshalers() {
+	type Embedded struct {
+		Name string `json:"name"`
+	}
+	type Config struct {
+		Embedded
+		Updated time.Time `json:"updated"`
+		hidden  string
+	}
+
+	now := time.Now()
+	s.Equal([]string{"name", "updated"}, diffConfig(
+		&Config{Embedded: Embedded{Name: "a"}, Updated: now, hidden: "a"},
+		&Config{Embedded: Embedded{Name: "b"}, Updated: now.Add(time.Second), hidden: "b"},
+	))
+}
+
+func (s *DiffSuite) TestPathAffected() {
+	s.True(pathAffected("sqs_queue.region", ""))
+	s.True(pathAffected("sqs_queue.region", "sqs_queue"))
+	s.True(pathAffected("sqs_queue", "sqs_queue.region"))
+	s.True(pathAffected("storage_config_slice[1].provider", "storage_config_slice"))
+	s.True(pathAffected("storage_config_slice", "storage_config_slice[1]"))
+	s.False(pathAffected("sqs_queue_optional.region", "sqs_queue"))
+	s.False(pathAffected("sqs_client.region", "sqs_queue"))
+}
@@ -1,9 +1,9 @@
 package remoteconfig
 
 type DynamoDBClientConfig struct {
-	Region     *AWSRegion `json:"region,omitempty"`
-	Endpoint   *string    `json:"endpoint,omitempty" remoteconfig:"optional"`
-	DisableSSL *bool      `json:"disable_ssl,omit" remoteconfig:"optional"`
+	Region     *AWSRegion `json:"region,omitempty" yaml:"region,omitempty"`
+	Endpoint   *string    `json:"endpoint,omitempty" yaml:"endpoint,omitempty" remoteconfig:"optional,required_with=DisableSSL"`
+	DisableSSL *bool      `json:"disable_ssl,omit" yaml:"disable_ssl,omitempty" remoteconfig:"optional,default=false"`
 }
 
 func (d DynamoDBClientConfig) GetRegion() AWSRegion {
@@ -1,7 +1,6 @@
 package remoteconfig
 
 import (
-	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -52,6 +51,24 @@ func (s *DynamoDBClientConfigSuite) TestValidateWithoutEndpoint() {
 	assert.Nil(s.T(), err)
 }
 
+func (s *DynamoDBClientConfigSuite) TestValidateErrorDisableSSLWithoutEndpoint() {
+	region := VALID_DYNAMODB_CLIENT_REGION
+	disableSSL := true
+
+	d := &DynamoDBClientConfig{
+		Region:     &region,
+		DisableSSL: &disableSSL,
+	}
+
+	err := validateConfigWithReflection(d)
+	assert.NotNil(s.T(), err)
+	assert.EqualError(s.T(), err, "Field: Endpoint, required when DisableSSL is set")
+
+	disableSSL = false
+	err = validateConfigWithReflection(d)
+	assert.Nil(s.T(), err)
+}
+
 func (s *DynamoDBClientConfigSuite) TestValidateErrorEmptyEndpoint() {
 	region := VALID_DYNAMODB_CLIENT_REGION
 	endpoint := ""
@@ -63,7 +80,7 @@ func (s *DynamoDBClientConfigSuite) TestValidateErrorEmptyEndpoint() {
 
 	err := validateConfigWithReflection(d)
 	assert.NotNil(s.T(), err)
-	assert.Equal(s.T(), errors.New("String Field: Endpoint, contains an empty string"), err)
+	assert.EqualError(s.T(), err, "String Field: Endpoint, contains an empty string")
 }
 
 func (s *DynamoDBClientConfigSuite) TestValidateErrorRegion() {
@@ -75,7 +92,7 @@ func (s *DynamoDBClientConfigSuite) TestValidateErrorRegion() {
 
 	err := validateConfigWithReflection(d)
 	assert.NotNil(s.T(), err)
-	assert.Equal(s.T(), errors.New("Validater Field: Region, failed to validate with error, Region cannot be empty"), err)
+	assert.EqualError(s.T(), err, "Validater Field: Region, failed to validate with error, Region cannot be empty")
 }
 
 func (s *DynamoDBClientConfigSuite) TestGetRegion() {
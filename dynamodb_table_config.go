@@ -1,7 +1,7 @@
 package remoteconfig
 
 type DynamoDBTableConfig struct {
-	TableName *string `mapstructure:"table_name" json:"table_name,omitempty"`
+	TableName *string `mapstructure:"table_name" json:"table_name,omitempty" yaml:"table_name,omitempty"`
 }
 
 func (d DynamoDBTableConfig) GetTableName() string {
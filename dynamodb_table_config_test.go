@@ -1,7 +1,6 @@
 package remoteconfig
 
 import (
-	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -46,7 +45,7 @@ func (s *DynamoDBTableConfigSuite) TestValidateErrorTableName() {
 
 	err := validateConfigWithReflection(d)
 	assert.NotNil(s.T(), err)
-	assert.Equal(s.T(), errors.New("String Field: TableName, contains an empty string"), err)
+	assert.EqualError(s.T(), err, "String Field: TableName, contains an empty string")
 }
 
 func (s *DynamoDBTableConfigSuite) TestGetTableName() {
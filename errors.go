@@ -0,0 +1,34 @@
+package remoteconfig
+
+import "errors"
+
+// Classes of load failure, match them with errors.Is. The typed errors carry the detail, get them with errors.As.
+var (
+	// The config document doesn't exist, a 404 response or a missing file
+	ErrNotFound = errors.New("Config not found")
+	// The config request got a non-200 OK response, see HTTPStatusError for the status code
+	ErrHTTPStatus = errors.New("Config request returned non-200 OK status")
+	// The config document couldn't be decoded, see DecodeError. Errors reading the document don't match it.
+	ErrDecode = errors.New("Config failed to decode")
+	// The decoded config failed to validate, see ValidationError and ValidationErrors
+	ErrValidation = errors.New("Config failed to validate")
+	// The load ran past its context deadline or the client timeout, see TimeoutError
+	ErrTimeout = errors.New("Config load timed out")
+)
+
+// Marks an error from a source as ErrNotFound, the original error is kept for errors.Is and errors.As.
+type notFoundError struct {
+	err error
+}
+
+func (e *notFoundError) Error() string {
+	return e.err.Error()
+}
+
+func (e *notFoundError) Unwrap() error {
+	return e.err
+}
+
+func (e *notFoundError) Is(target error) bool {
+	return target == ErrNotFound
+}
@@ -0,0 +1,136 @@
+package remoteconfig
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ErrorsSuite struct {
+	suite.Suite
+}
+
+func TestErrorsSuite(t *testing.T) {
+	suite.Run(t, new(ErrorsSuite))
+}
+
+func (s *ErrorsSuite) TestHTTPStatusErrorNotFound() {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer ts.Close()
+
+	err := LoadConfigFromURL(ts.URL, &SampleConfig{})
+	s.True(errors.Is(err, ErrNotFound))
+	s.True(errors.Is(err, ErrHTTPStatus))
+	s.False(errors.Is(err, ErrDecode))
+	s.False(errors.Is(err, ErrValidation))
+
+	var statusErr *HTTPStatusError
+	s.True(errors.As(err, &statusErr))
+	s.Equal(http.StatusNotFound, statusErr.StatusCode)
+}
+
+func (s *ErrorsSuite) TestHTTPStatusError() {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer ts.Close()
+
+	err := LoadConfigFromURL(ts.URL, &SampleConfig{})
+	s.True(errors.Is(err, ErrHTTPStatus))
+	s.False(errors.Is(err, ErrNotFound))
+
+	var statusErr *HTTPStatusError
+	s.True(errors.As(err, &statusErr))
+	s.Equal(http.StatusForbidden, statusErr.StatusCode)
+}
+
+func (s *ErrorsSuite) TestFileNotFound() {
+	dir, err := ioutil.TempDir("", "remoteconfig")
+	s.Nil(err)
+	defer os.RemoveAll(dir)
+
+	err = Load(context.Background(), "file://"+filepath.Join(dir, "missing.json"), &SampleConfig{})
+	s.True(errors.Is(err, ErrNotFound))
+	s.True(errors.Is(err, os.ErrNotExist))
+	s.False(errors.Is(err, ErrHTTPStatus))
+
+	var pathErr *os.PathError
+	s.True(errors.As(err, &pathErr))
+}
+
+func (s *ErrorsSuite) TestCustomSourceNotFound() {
+	RegisterSource("errorstest", &memorySource{})
+	defer func() {
+		sourcesMutex.Lock()
+		delete(sources, "errorstest")
+		sourcesMutex.Unlock()
+	}()
+
+	err := Load(context.Background(), "errorstest://missing.json", &SampleConfig{})
+	s.True(errors.Is(err, ErrNotFound))
+	s.EqualError(err, "Document errorstest://missing.json, Config not found")
+}
+
+func (s *ErrorsSuite) TestDecodeError() {
+	err := ReadJSONValidate(bytes.NewBufferString("Not JSON"), &SampleConfig{})
+	s.True(errors.Is(err, ErrDecode))
+	s.False(errors.Is(err, ErrValidation))
+
+	var decodeErr *DecodeError
+	s.True(errors.As(err, &decodeErr))
+}
+
+func (s *ErrorsSuite) TestValidationError() {
+	err := ReadJSONValidate(bytes.NewBufferString("{}"), &SampleConfig{})
+	s.True(errors.Is(err, ErrValidation))
+	s.False(errors.Is(err, ErrDecode))
+
+	err = ReadValidate(bytes.NewBufferString("{}"), FORMAT_JSON, &SampleConfig{}, WithAllValidationErrors())
+	s.True(errors.Is(err, ErrValidation))
+
+	var validationErrs ValidationErrors
+	s.True(errors.As(err, &validationErrs))
+}
+
+func (s *ErrorsSuite) TestValidationErrorValidater() {
+	region := AWSRegion("invalidregion")
+	err := validateConfigWithReflection(&SQSQueueConfig{Region: &region})
+	s.True(errors.Is(err, ErrValidation))
+	s.True(errors.Is(err, ErrAWSRegionInvalid))
+}
+
+func (s *ErrorsSuite) TestTimeoutError() {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(100 * time.Millisecond)
+	}))
+	defer ts.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	err := LoadConfigFromURLWithContext(ctx, ts.URL, &SampleConfig{})
+	s.True(errors.Is(err, ErrTimeout))
+	s.False(errors.Is(err, ErrNotFound))
+}
+
+func (s *ErrorsSuite) TestWrapSourceError() {
+	s.Nil(wrapSourceError(nil))
+
+	err := errors.New("other")
+	s.Equal(err, wrapSourceError(err))
+
+	// Already marked errors aren't wrapped twice
+	notFound := &notFoundError{err: os.ErrNotExist}
+	s.Equal(notFound, wrapSourceError(notFound))
+}
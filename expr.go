@@ -0,0 +1,544 @@
+package remoteconfig
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// A parsed validation expression, used by the expr tag and RegisterRule which describes the syntax.
+type expr struct {
+	source string
+	root   exprNode
+}
+
+type exprNode interface {
+	eval(env *exprEnv) (interface{}, error)
+}
+
+// Values self and parent refer to while evaluating.
+type exprEnv struct {
+	self   reflect.Value
+	parent reflect.Value
+}
+
+// Parses an expression.
+func parseExpr(source string) (*expr, error) {
+	tokens, err := lexExpr(source)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &exprParser{tokens: tokens}
+	root, err := p.parseBinary(1)
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != exprTokenEOF {
+		return nil, fmt.Errorf("unexpected '%s' at position %d", tok.text, tok.pos)
+	}
+
+	return &expr{source: source, root: root}, nil
+}
+
+// Evaluates the expression, which must give a bool.
+func (e *expr) eval(self reflect.Value, parent reflect.Value) (bool, error) {
+	result, err := e.root.eval(&exprEnv{self: self, parent: parent})
+	if err != nil {
+		return false, err
+	}
+
+	b, ok := result.(bool)
+	if !ok {
+		return false, fmt.Errorf("expression gives %s, not bool", exprTypeName(result))
+	}
+	return b, nil
+}
+
+func (e *expr) String() string {
+	return e.source
+}
+
+type exprTokenKind int
+
+const (
+	exprTokenEOF exprTokenKind = iota
+	exprTokenNumber
+	exprTokenString
+	exprTokenIdent
+	exprTokenOperator
+)
+
+type exprToken struct {
+	kind exprTokenKind
+	text string
+	pos  int
+}
+
+var exprOperators = []string{"||", "&&", "==", "!=", "<=", ">=", "<", ">", "!", "+", "-", "*", "/", "(", ")", "."}
+
+// Binary operator precedence, higher binds tighter
+var exprPrecedence = map[string]int{
+	"||": 1,
+	"&&": 2,
+	"==": 3,
+	"!=": 3,
+	"<":  4,
+	"<=": 4,
+	">":  4,
+	">=": 4,
+	"+":  5,
+	"-":  5,
+	"*":  6,
+	"/":  6,
+}
+
+func lexExpr(source string) ([]exprToken, error) {
+	tokens := []exprToken{}
+
+	for i := 0; i < len(source); {
+		c := source[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+
+		case isExprDigit(c):
+			j := i
+			for j < len(source) && (isExprDigit(source[j]) || source[j] == '.') {
+				j++
+			}
+			tokens = append(tokens, exprToken{kind: exprTokenNumber, text: source[i:j], pos: i})
+			i = j
+
+		case c == '"':
+			j := i + 1
+			for j < len(source) && source[j] != '"' {
+				if source[j] == '\\' {
+					j++
+				}
+				j++
+			}
+			if j >= len(source) {
+				return nil, fmt.Errorf("unterminated string at position %d", i)
+			}
+			tokens = append(tokens, exprToken{kind: exprTokenString, text: source[i : j+1], pos: i})
+			i = j + 1
+
+		case isExprLetter(c):
+			j := i
+			for j < len(source) && (isExprLetter(source[j]) || isExprDigit(source[j])) {
+				j++
+			}
+			tokens = append(tokens, exprToken{kind: exprTokenIdent, text: source[i:j], pos: i})
+			i = j
+
+		default:
+			op := ""
+			for _, candidate := range exprOperators {
+				if strings.HasPrefix(source[i:], candidate) {
+					op = candidate
+					break
+				}
+			}
+			if op == "" {
+				return nil, fmt.Errorf("unexpected character '%c' at position %d", c, i)
+			}
+			tokens = append(tokens, exprToken{kind: exprTokenOperator, text: op, pos: i})
+			i += len(op)
+		}
+	}
+
+	return append(tokens, exprToken{kind: exprTokenEOF, text: "end of expression", pos: len(source)}), nil
+}
+
+func isExprDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isExprLetter(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+type exprParser struct {
+	tokens []exprToken
+	pos    int
+}
+
+func (p *exprParser) peek() exprToken {
+	return p.tokens[p.pos]
+}
+
+func (p *exprParser) next() exprToken {
+	tok := p.tokens[p.pos]
+	if tok.kind != exprTokenEOF {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *exprParser) isOperator(op string) bool {
+	tok := p.peek()
+	return tok.kind == exprTokenOperator && tok.text == op
+}
+
+func (p *exprParser) expect(op string) error {
+	if !p.isOperator(op) {
+		tok := p.peek()
+		return fmt.Errorf("expected '%s' at position %d, found '%s'", op, tok.pos, tok.text)
+	}
+	p.next()
+	return nil
+}
+
+// Parses binary operators that bind at least as tightly as minPrecedence, by precedence climbing.
+func (p *exprParser) parseBinary(minPrecedence int) (exprNode, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		tok := p.peek()
+		precedence, ok := exprPrecedence[tok.text]
+		if tok.kind != exprTokenOperator || !ok || precedence < minPrecedence {
+			return left, nil
+		}
+		p.next()
+
+		right, err := p.parseBinary(precedence + 1)
+		if err != nil {
+			return nil, err
+		}
+		left = &exprBinary{op: tok.text, x: left, y: right}
+	}
+}
+
+func (p *exprParser) parseUnary() (exprNode, error) {
+	if p.isOperator("!") || p.isOperator("-") {
+		op := p.next().text
+		x, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &exprUnary{op: op, x: x}, nil
+	}
+	return p.parsePostfix()
+}
+
+func (p *exprParser) parsePostfix() (exprNode, error) {
+	x, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.isOperator(".") {
+		p.next()
+		tok := p.next()
+		if tok.kind != exprTokenIdent {
+			return nil, fmt.Errorf("expected field name at position %d, found '%s'", tok.pos, tok.text)
+		}
+		x = &exprField{x: x, name: tok.text}
+	}
+	return x, nil
+}
+
+func (p *exprParser) parsePrimary() (exprNode, error) {
+	tok := p.next()
+
+	switch tok.kind {
+	case exprTokenNumber:
+		f, err := strconv.ParseFloat(tok.text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number '%s' at position %d", tok.text, tok.pos)
+		}
+		return &exprLiteral{value: f}, nil
+
+	case exprTokenString:
+		s, err := strconv.Unquote(tok.text)
+		if err != nil {
+			return nil, fmt.Errorf("invalid string %s at position %d", tok.text, tok.pos)
+		}
+		return &exprLiteral{value: s}, nil
+
+	case exprTokenIdent:
+		switch tok.text {
+		case "true":
+			return &exprLiteral{value: true}, nil
+		case "false":
+			return &exprLiteral{value: false}, nil
+		case "nil":
+			return &exprLiteral{value: nil}, nil
+		case "self", "parent":
+			return &exprIdent{name: tok.text}, nil
+		case "len":
+			if err := p.expect("("); err != nil {
+				return nil, err
+			}
+			x, err := p.parseBinary(1)
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect(")"); err != nil {
+				return nil, err
+			}
+			return &exprLen{x: x}, nil
+		}
+		return nil, fmt.Errorf("unknown identifier '%s' at position %d", tok.text, tok.pos)
+
+	case exprTokenOperator:
+		if tok.text == "(" {
+			x, err := p.parseBinary(1)
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect(")"); err != nil {
+				return nil, err
+			}
+			return x, nil
+		}
+	}
+
+	return nil, fmt.Errorf("unexpected '%s' at position %d", tok.text, tok.pos)
+}
+
+type exprLiteral struct {
+	value interface{}
+}
+
+func (n *exprLiteral) eval(env *exprEnv) (interface{}, error) {
+	return n.value, nil
+}
+
+type exprIdent struct {
+	name string
+}
+
+func (n *exprIdent) eval(env *exprEnv) (interface{}, error) {
+	if n.name == "parent" {
+		return exprValue(env.parent), nil
+	}
+	return exprValue(env.self), nil
+}
+
+type exprField struct {
+	x    exprNode
+	name string
+}
+
+func (n *exprField) eval(env *exprEnv) (interface{}, error) {
+	x, err := n.x.eval(env)
+	if err != nil || x == nil {
+		return nil, err
+	}
+
+	v, ok := x.(reflect.Value)
+	if !ok || v.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("cannot access field %s of %s", n.name, exprTypeName(x))
+	}
+
+	// A field promoted through a nil embedded pointer is nil
+	if field, ok := exportedFieldByName(v, n.name); ok {
+		if !field.IsValid() {
+			return nil, nil
+		}
+		return exprValue(field), nil
+	}
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).PkgPath == "" && jsonFieldName(t.Field(i)) == n.name {
+			return exprValue(v.Field(i)), nil
+		}
+	}
+	return nil, fmt.Errorf("unknown field %s of %s", n.name, t)
+}
+
+type exprLen struct {
+	x exprNode
+}
+
+func (n *exprLen) eval(env *exprEnv) (interface{}, error) {
+	x, err := n.x.eval(env)
+	if err != nil {
+		return nil, err
+	}
+
+	switch x := x.(type) {
+	case nil:
+		return float64(0), nil
+	case string:
+		return float64(utf8.RuneCountInString(x)), nil
+	case reflect.Value:
+		switch x.Kind() {
+		case reflect.Slice, reflect.Array, reflect.Map:
+			return float64(x.Len()), nil
+		}
+	}
+	return nil, fmt.Errorf("len is not supported for %s", exprTypeName(x))
+}
+
+type exprUnary struct {
+	op string
+	x  exprNode
+}
+
+func (n *exprUnary) eval(env *exprEnv) (interface{}, error) {
+	x, err := n.x.eval(env)
+	if err != nil {
+		return nil, err
+	}
+
+	switch x := x.(type) {
+	case bool:
+		if n.op == "!" {
+			return !x, nil
+		}
+	case float64:
+		if n.op == "-" {
+			return -x, nil
+		}
+	}
+	return nil, fmt.Errorf("operator %s is not supported for %s", n.op, exprTypeName(x))
+}
+
+type exprBinary struct {
+	op string
+	x  exprNode
+	y  exprNode
+}
+
+func (n *exprBinary) eval(env *exprEnv) (interface{}, error) {
+	x, err := n.x.eval(env)
+	if err != nil {
+		return nil, err
+	}
+
+	// && and || short circuit, so the right hand side can rely on the left, i.e. self.A != nil && self.A.B > 1
+	if n.op == "&&" || n.op == "||" {
+		b, ok := x.(bool)
+		if !ok {
+			return nil, fmt.Errorf("operator %s is not supported for %s", n.op, exprTypeName(x))
+		}
+		if (n.op == "&&" && !b) || (n.op == "||" && b) {
+			return b, nil
+		}
+		y, err := n.y.eval(env)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := y.(bool); !ok {
+			return nil, fmt.Errorf("operator %s is not supported for %s", n.op, exprTypeName(y))
+		}
+		return y, nil
+	}
+
+	y, err := n.y.eval(env)
+	if err != nil {
+		return nil, err
+	}
+
+	switch n.op {
+	case "==":
+		return exprEqual(x, y), nil
+	case "!=":
+		return !exprEqual(x, y), nil
+	}
+
+	if xs, ok := x.(string); ok {
+		if ys, ok := y.(string); ok {
+			switch n.op {
+			case "+":
+				return xs + ys, nil
+			case "<":
+				return xs < ys, nil
+			case "<=":
+				return xs <= ys, nil
+			case ">":
+				return xs > ys, nil
+			case ">=":
+				return xs >= ys, nil
+			}
+		}
+	}
+
+	if xf, ok := x.(float64); ok {
+		if yf, ok := y.(float64); ok {
+			switch n.op {
+			case "+":
+				return xf + yf, nil
+			case "-":
+				return xf - yf, nil
+			case "*":
+				return xf * yf, nil
+			case "/":
+				if yf == 0 {
+					return nil, fmt.Errorf("division by zero")
+				}
+				return xf / yf, nil
+			case "<":
+				return xf < yf, nil
+			case "<=":
+				return xf <= yf, nil
+			case ">":
+				return xf > yf, nil
+			case ">=":
+				return xf >= yf, nil
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("operator %s is not supported for %s and %s", n.op, exprTypeName(x), exprTypeName(y))
+}
+
+// Converts a reflected value into the form expressions work with.
+// Pointers are followed, numbers become float64, string kinds become string, composite values stay a reflect.Value.
+func exprValue(v reflect.Value) interface{} {
+	for v.IsValid() && (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return nil
+	}
+	if isNilFixed(v) {
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		return v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		return v.Float()
+	case reflect.String:
+		return v.String()
+	}
+	return v
+}
+
+func exprEqual(x interface{}, y interface{}) bool {
+	xv, xok := x.(reflect.Value)
+	yv, yok := y.(reflect.Value)
+	if xok || yok {
+		return xok && yok && xv.CanInterface() && yv.CanInterface() && reflect.DeepEqual(xv.Interface(), yv.Interface())
+	}
+	return x == y
+}
+
+func exprTypeName(x interface{}) string {
+	switch x := x.(type) {
+	case nil:
+		return "nil"
+	case float64:
+		return "number"
+	case reflect.Value:
+		return x.Type().String()
+	}
+	return reflect.TypeOf(x).String()
+}
@@ -0,0 +1,144 @@
+package remoteconfig
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ExprSuite struct {
+	suite.Suite
+}
+
+func TestExprSuite(t *testing.T) {
+	suite.Run(t, new(ExprSuite))
+}
+
+type ExprSubConfig struct {
+	Name *string `json:"name"`
+}
+
+type exprHiddenConfig struct {
+	hidden string
+	Shown  string
+}
+
+type ExprConfig struct {
+	Expiry   *uint                     `json:"expiry"`
+	Endpoint *string                   `json:"endpoint"`
+	Region   AWSRegion                 `json:"region"`
+	Enabled  bool                      `json:"enabled"`
+	Ratio    float64                   `json:"ratio"`
+	Hosts    []string                  `json:"hosts"`
+	Subs     map[string]*ExprSubConfig `json:"subs"`
+	Sub      *ExprSubConfig            `json:"sub"`
+	Hidden   exprHiddenConfig          `json:"hidden"`
+}
+
+func (s *ExprSuite) eval(source string, self interface{}) (bool, error) {
+	e, err := parseExpr(source)
+	s.Require().Nil(err)
+	return e.eval(reflect.ValueOf(self), reflect.Value{})
+}
+
+func (s *ExprSuite) assertTrue(source string, self interface{}) {
+	ok, err := s.eval(source, self)
+	s.Nil(err, source)
+	s.True(ok, source)
+}
+
+func (s *ExprSuite) assertFalse(source string, self interface{}) {
+	ok, err := s.eval(source, self)
+	s.Nil(err, source)
+	s.False(ok, source)
+}
+
+func (s *ExprSuite) TestEval() {
+	expiry := uint(3600)
+	endpoint := "http://localhost"
+	c := &ExprConfig{
+		Expiry:   &expiry,
+		Endpoint: &endpoint,
+		Region:   AWS_REGION_US_EAST_1,
+		Enabled:  true,
+		Ratio:    0.5,
+		Hosts:    []string{"a", "b"},
+	}
+
+	s.assertTrue(`self.Expiry <= 604800 || self.Endpoint != ""`, c)
+	s.assertTrue(`self.expiry == 3600 && self.region == "us-east-1"`, c)
+	s.assertTrue(`self.Enabled && !(self.Ratio > 1)`, c)
+	s.assertTrue(`self.Expiry / 60 == 60 && self.Expiry - 600 * 2 + 1 == 2401`, c)
+	s.assertTrue(`-self.Ratio < 0`, c)
+	s.assertTrue(`len(self.Hosts) == 2 && len(self.Endpoint) == 16 && len(self.Subs) == 0`, c)
+	s.assertTrue(`self.Endpoint + "/s3" == "http://localhost/s3"`, c)
+	s.assertTrue(`"a" < "b" && 2 >= 2 && 1 > 0.5`, c)
+	s.assertFalse(`self.Expiry > 604800`, c)
+	s.assertFalse(`true && false || false`, c)
+	s.assertTrue(`false && false || true`, c)
+}
+
+func (s *ExprSuite) TestEvalNil() {
+	c := &ExprConfig{}
+	s.assertTrue(`self.Expiry == nil && self.Endpoint != ""`, c)
+	s.assertTrue(`self.Sub.Name == nil`, c)
+	s.assertTrue(`self.Hosts == nil && self.Subs == nil`, c)
+
+	// The right hand side isn't evaluated when the left decides the result
+	s.assertTrue(`self.Expiry == nil || self.Expiry > "a"`, c)
+	s.assertFalse(`self.Expiry != nil && self.Expiry > "a"`, c)
+}
+
+func (s *ExprSuite) TestEvalParent() {
+	e, err := parseExpr(`self < parent.Expiry`)
+	s.Nil(err)
+
+	expiry := uint(60)
+	ok, err := e.eval(reflect.ValueOf(30), reflect.ValueOf(&ExprConfig{Expiry: &expiry}))
+	s.Nil(err)
+	s.True(ok)
+}
+
+func (s *ExprSuite) TestEvalErrors() {
+	c := &ExprConfig{}
+	for source, msg := range map[string]string{
+		`self.Ratio`:          "expression gives number, not bool",
+		`self.Missing == 1`:   "unknown field Missing of remoteconfig.ExprConfig",
+		`self.Hidden.hidden`:  "unknown field hidden of remoteconfig.exprHiddenConfig",
+		`self.Ratio.Name`:     "cannot access field Name of number",
+		`self.Region > 1`:     "operator > is not supported for string and number",
+		`self.Ratio || true`:  "operator || is not supported for number",
+		`true && self.Ratio`:  "operator && is not supported for number",
+		`!self.Ratio`:         "operator ! is not supported for number",
+		`len(self.Ratio) > 0`: "len is not supported for number",
+		`1 / self.Ratio > 0`:  "division by zero",
+		`self.Enabled < true`: "operator < is not supported for bool and bool",
+	} {
+		_, err := s.eval(source, c)
+		s.EqualError(err, msg, source)
+	}
+}
+
+func (s *ExprSuite) TestParseErrors() {
+	for source, msg := range map[string]string{
+		`self.Expiry <`:       "unexpected 'end of expression' at position 13",
+		`self.Expiry == 1 1`:  "unexpected '1' at position 17",
+		`(self.Expiry == 1`:   "expected ')' at position 17, found 'end of expression'",
+		`self. == 1`:          "expected field name at position 6, found '=='",
+		`other.Expiry == 1`:   "unknown identifier 'other' at position 0",
+		`len self`:            "expected '(' at position 4, found 'self'",
+		`self.Name == "abc`:   "unterminated string at position 13",
+		`self.Name == 'abc'`:  "unexpected character ''' at position 13",
+		`self.Expiry == 1..2`: "invalid number '1..2' at position 15",
+	} {
+		_, err := parseExpr(source)
+		s.EqualError(err, msg, source)
+	}
+}
+
+func (s *ExprSuite) TestString() {
+	e, err := parseExpr(`self.Expiry > 1`)
+	s.Nil(err)
+	s.Equal(`self.Expiry > 1`, e.String())
+}
@@ -0,0 +1,45 @@
+package remoteconfig
+
+import (
+	"context"
+	"io"
+	"net/url"
+	"os"
+)
+
+// Source for file:// URLs, i.e. file:///etc/service/config.json
+type FileSource struct{}
+
+func (f *FileSource) Open(ctx context.Context, location *url.URL) (io.ReadCloser, *SourceMetadata, error) {
+	return f.OpenIfModified(ctx, location, nil)
+}
+
+// Returns ErrNotModified when the file's modification time matches the previous metadata.
+func (f *FileSource) OpenIfModified(ctx context.Context, location *url.URL, previous *SourceMetadata) (io.ReadCloser, *SourceMetadata, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	path := location.Path
+	if path == "" {
+		path = location.Opaque
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, nil, err
+	}
+
+	if previous != nil && !previous.LastModified.IsZero() && info.ModTime().Equal(previous.LastModified) {
+		file.Close()
+		return nil, nil, ErrNotModified
+	}
+
+	return file, &SourceMetadata{LastModified: info.ModTime()}, nil
+}
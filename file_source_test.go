@@ -0,0 +1,105 @@
+package remoteconfig
+
+import (
+	"context"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type FileSourceSuite struct {
+	suite.Suite
+	dir string
+}
+
+func TestFileSourceSuite(t *testing.T) {
+	suite.Run(t, new(FileSourceSuite))
+}
+
+func (s *FileSourceSuite) SetupTest() {
+	dir, err := ioutil.TempDir("", "remoteconfig")
+	s.Nil(err)
+	s.dir = dir
+}
+
+func (s *FileSourceSuite) TearDownTest() {
+	os.RemoveAll(s.dir)
+}
+
+func (s *FileSourceSuite) TestOpen() {
+	path := filepath.Join(s.dir, "config.json")
+	s.Nil(ioutil.WriteFile(path, []byte(validConfigJSON), 0644))
+
+	location, _ := url.Parse("file://" + path)
+	body, metadata, err := (&FileSource{}).Open(context.Background(), location)
+	s.Nil(err)
+	defer body.Close()
+
+	data, err := ioutil.ReadAll(body)
+	s.Nil(err)
+	s.Equal(validConfigJSON, string(data))
+	s.False(metadata.LastModified.IsZero())
+}
+
+func (s *FileSourceSuite) TestLoad() {
+	path := filepath.Join(s.dir, "config.json")
+	s.Nil(ioutil.WriteFile(path, []byte(validConfigJSON), 0644))
+
+	c := &SampleConfig{}
+	err := Load(context.Background(), "file://"+path, c)
+	s.Nil(err)
+	s.Equal("testStr", c.Str)
+}
+
+func (s *FileSourceSuite) TestLoadFormatFromExtension() {
+	path := filepath.Join(s.dir, "config.toml")
+	s.Nil(ioutil.WriteFile(path, []byte(validConfigTOML), 0644))
+
+	c := &SampleConfig{}
+	err := Load(context.Background(), "file://"+path, c)
+	s.Nil(err)
+	s.Equal("testStr", c.Str)
+}
+
+func (s *FileSourceSuite) TestOpenErrorNotExist() {
+	location, _ := url.Parse("file://" + filepath.Join(s.dir, "missing.json"))
+	body, metadata, err := (&FileSource{}).Open(context.Background(), location)
+	s.Nil(body)
+	s.Nil(metadata)
+	s.True(os.IsNotExist(err))
+}
+
+func (s *FileSourceSuite) TestOpenErrorContextCanceled() {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	location, _ := url.Parse("file://" + filepath.Join(s.dir, "config.json"))
+	_, _, err := (&FileSource{}).Open(ctx, location)
+	s.Equal(context.Canceled, err)
+}
+
+func (s *FileSourceSuite) TestOpenIfModified() {
+	path := filepath.Join(s.dir, "config.json")
+	s.Nil(ioutil.WriteFile(path, []byte(validConfigJSON), 0644))
+	location, _ := url.Parse("file://" + path)
+
+	body, metadata, err := (&FileSource{}).OpenIfModified(context.Background(), location, nil)
+	s.Nil(err)
+	body.Close()
+
+	body, _, err = (&FileSource{}).OpenIfModified(context.Background(), location, metadata)
+	s.Nil(body)
+	s.Equal(ErrNotModified, err)
+
+	modified := metadata.LastModified.Add(time.Second)
+	s.Nil(os.Chtimes(path, modified, modified))
+	body, metadata, err = (&FileSource{}).OpenIfModified(context.Background(), location, metadata)
+	s.Nil(err)
+	body.Close()
+	s.True(modified.Equal(metadata.LastModified))
+}
@@ -0,0 +1,103 @@
+package remoteconfig
+
+import (
+	"errors"
+	"mime"
+	"path"
+	"strings"
+)
+
+type Format string
+
+const (
+	FORMAT_JSON Format = "json"
+	FORMAT_YAML Format = "yaml"
+	FORMAT_TOML Format = "toml"
+)
+
+var Formats = []Format{
+	FORMAT_JSON,
+	FORMAT_YAML,
+	FORMAT_TOML,
+}
+
+var (
+	ErrFormatEmptyString = errors.New("Format cannot be empty")
+	ErrFormatInvalid     = errors.New("Format is invalid")
+)
+
+var formatContentTypes = map[string]Format{
+	"application/json":   FORMAT_JSON,
+	"text/json":          FORMAT_JSON,
+	"application/yaml":   FORMAT_YAML,
+	"application/x-yaml": FORMAT_YAML,
+	"text/yaml":          FORMAT_YAML,
+	"text/x-yaml":        FORMAT_YAML,
+	"application/toml":   FORMAT_TOML,
+	"text/toml":          FORMAT_TOML,
+	"text/x-toml":        FORMAT_TOML,
+}
+
+var formatExtensions = map[string]Format{
+	".json": FORMAT_JSON,
+	".yaml": FORMAT_YAML,
+	".yml":  FORMAT_YAML,
+	".toml": FORMAT_TOML,
+}
+
+func (f *Format) UnmarshalText(data []byte) error {
+	fString := string(data[:])
+	*f = (Format)(strings.ToLower(fString))
+	return f.Validate()
+}
+
+func (f Format) Validate() error {
+	if f == "" {
+		return ErrFormatEmptyString
+	}
+
+	if f != FORMAT_JSON && f != FORMAT_YAML && f != FORMAT_TOML {
+		return ErrFormatInvalid
+	}
+
+	return nil
+}
+
+// Returns the format for a Content-Type header value, or an empty Format if it isn't recognized.
+// Structured syntax suffixes are recognized, i.e. application/vnd.service+json.
+func FormatFromContentType(contentType string) Format {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return ""
+	}
+
+	if f, ok := formatContentTypes[mediaType]; ok {
+		return f
+	}
+	if i := strings.LastIndex(mediaType, "+"); i >= 0 {
+		if f := Format(mediaType[i+1:]); f.Validate() == nil {
+			return f
+		}
+	}
+	return ""
+}
+
+// Returns the format for the extension of a file path or object key, or an empty Format if it isn't recognized.
+func FormatFromExtension(filePath string) Format {
+	return formatExtensions[strings.ToLower(path.Ext(filePath))]
+}
+
+// Picks the format of a document, an explicit format wins over the Content-Type,
+// which wins over the file extension. Defaults to JSON.
+func detectFormat(explicit Format, contentType string, filePath string) Format {
+	if explicit != "" {
+		return explicit
+	}
+	if f := FormatFromContentType(contentType); f != "" {
+		return f
+	}
+	if f := FormatFromExtension(filePath); f != "" {
+		return f
+	}
+	return FORMAT_JSON
+}
@@ -0,0 +1,58 @@
+package remoteconfig
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type FormatSuite struct {
+	suite.Suite
+}
+
+func TestFormatSuite(t *testing.T) {
+	suite.Run(t, new(FormatSuite))
+}
+
+func (s *FormatSuite) TestValidate() {
+	for _, f := range Formats {
+		s.Nil(f.Validate())
+	}
+	s.Equal(ErrFormatEmptyString, Format("").Validate())
+	s.Equal(ErrFormatInvalid, Format("xml").Validate())
+}
+
+func (s *FormatSuite) TestUnmarshalText() {
+	var f Format
+	s.Nil(f.UnmarshalText([]byte("YAML")))
+	s.Equal(FORMAT_YAML, f)
+	s.Equal(ErrFormatInvalid, f.UnmarshalText([]byte("xml")))
+}
+
+func (s *FormatSuite) TestFormatFromContentType() {
+	s.Equal(FORMAT_JSON, FormatFromContentType("application/json; charset=utf-8"))
+	s.Equal(FORMAT_YAML, FormatFromContentType("application/x-yaml"))
+	s.Equal(FORMAT_YAML, FormatFromContentType("text/yaml"))
+	s.Equal(FORMAT_TOML, FormatFromContentType("application/toml"))
+	s.Equal(FORMAT_JSON, FormatFromContentType("application/vnd.service.config+json"))
+	s.Equal(FORMAT_YAML, FormatFromContentType("application/vnd.service.config+yaml"))
+	s.Equal(Format(""), FormatFromContentType("text/plain; charset=utf-8"))
+	s.Equal(Format(""), FormatFromContentType("binary/octet-stream"))
+	s.Equal(Format(""), FormatFromContentType(""))
+}
+
+func (s *FormatSuite) TestFormatFromExtension() {
+	s.Equal(FORMAT_JSON, FormatFromExtension("/path/config.json"))
+	s.Equal(FORMAT_YAML, FormatFromExtension("path/config.yaml"))
+	s.Equal(FORMAT_YAML, FormatFromExtension("path/config.YML"))
+	s.Equal(FORMAT_TOML, FormatFromExtension("config.toml"))
+	s.Equal(Format(""), FormatFromExtension("path/config"))
+	s.Equal(Format(""), FormatFromExtension("path/config.ini"))
+}
+
+func (s *FormatSuite) TestDetectFormat() {
+	s.Equal(FORMAT_TOML, detectFormat(FORMAT_TOML, "application/json", "config.yaml"))
+	s.Equal(FORMAT_JSON, detectFormat("", "application/json", "config.yaml"))
+	s.Equal(FORMAT_YAML, detectFormat("", "text/plain", "config.yaml"))
+	s.Equal(FORMAT_JSON, detectFormat("", "text/plain", "config"))
+}
@@ -1,5 +1,9 @@
 module github.com/zencoder/go-remote-config
 
-go 1.13
+go 1.16
 
-require github.com/stretchr/testify v0.0.0-20151102014159-c478a808a1b3
+require (
+	github.com/BurntSushi/toml v1.2.1
+	github.com/stretchr/testify v0.0.0-20151102014159-c478a808a1b3
+	gopkg.in/yaml.v3 v3.0.1
+)
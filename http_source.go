@@ -0,0 +1,145 @@
+package remoteconfig
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Returned when a config request gets a non-200 OK response.
+// Matches ErrHTTPStatus with errors.Is, and ErrNotFound for a 404 Not Found.
+type HTTPStatusError struct {
+	URL        string
+	StatusCode int
+	RetryAfter time.Duration // Parsed from the Retry-After header, 0 when not sent
+}
+
+func newHTTPStatusError(requestURL string, resp *http.Response) *HTTPStatusError {
+	return &HTTPStatusError{
+		URL:        requestURL,
+		StatusCode: resp.StatusCode,
+		RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()),
+	}
+}
+
+func (e *HTTPStatusError) Error() string {
+	return fmt.Sprintf("Request to '%s' returned non-200 OK status '%d: %s'", e.URL, e.StatusCode, http.StatusText(e.StatusCode))
+}
+
+func (e *HTTPStatusError) Is(target error) bool {
+	return target == ErrHTTPStatus || (target == ErrNotFound && e.StatusCode == http.StatusNotFound)
+}
+
+// Parses a Retry-After header, which is either a number of seconds or an HTTP date.
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if t, err := http.ParseTime(value); err == nil && t.After(now) {
+		return t.Sub(now)
+	}
+	return 0
+}
+
+// Source for http:// and https:// URLs.
+type HTTPSource struct {
+	Client    *http.Client // Defaults to http.DefaultClient
+	Header    http.Header  // Extra request headers, i.e. Authorization
+	UserAgent string
+}
+
+func (h *HTTPSource) Open(ctx context.Context, location *url.URL) (io.ReadCloser, *SourceMetadata, error) {
+	return h.OpenIfModified(ctx, location, nil)
+}
+
+// Sends If-None-Match and If-Modified-Since from the previous metadata, a 304 Not Modified response returns ErrNotModified.
+func (h *HTTPSource) OpenIfModified(ctx context.Context, location *url.URL, previous *SourceMetadata) (io.ReadCloser, *SourceMetadata, error) {
+	req, err := http.NewRequest(http.MethodGet, location.String(), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	for k, v := range h.Header {
+		req.Header[k] = append([]string{}, v...)
+	}
+	if h.UserAgent != "" {
+		req.Header.Set("User-Agent", h.UserAgent)
+	}
+	setConditionalHeaders(req, previous)
+
+	resp, err := h.getClient().Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if resp.StatusCode == http.StatusNotModified {
+		resp.Body.Close()
+		return nil, nil, ErrNotModified
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, nil, newHTTPStatusError(location.String(), resp)
+	}
+
+	return resp.Body, responseMetadata(resp), nil
+}
+
+func (h *HTTPSource) getClient() *http.Client {
+	if h.Client != nil {
+		return h.Client
+	}
+	return http.DefaultClient
+}
+
+// Returns a copy of the source with the HTTP load options applied on top.
+func (h *HTTPSource) withOptions(o *loadOptions) *HTTPSource {
+	source := &HTTPSource{
+		Client:    h.Client,
+		Header:    http.Header{},
+		UserAgent: h.UserAgent,
+	}
+	for k, v := range h.Header {
+		source.Header[k] = v
+	}
+	for k, v := range o.header {
+		source.Header[k] = v
+	}
+	if o.client != nil {
+		source.Client = o.client
+	}
+	if o.userAgent != "" {
+		source.UserAgent = o.userAgent
+	}
+	return source
+}
+
+func responseMetadata(resp *http.Response) *SourceMetadata {
+	metadata := &SourceMetadata{
+		Version:     resp.Header.Get("X-Amz-Version-Id"),
+		ETag:        resp.Header.Get("ETag"),
+		ContentType: resp.Header.Get("Content-Type"),
+	}
+	if lastModified, err := http.ParseTime(resp.Header.Get("Last-Modified")); err == nil {
+		metadata.LastModified = lastModified
+	}
+	return metadata
+}
+
+// Makes the request conditional on the document having changed since the previous metadata, when there is any.
+func setConditionalHeaders(req *http.Request, previous *SourceMetadata) {
+	if previous == nil {
+		return
+	}
+	if previous.ETag != "" {
+		req.Header.Set("If-None-Match", previous.ETag)
+	}
+	if !previous.LastModified.IsZero() {
+		req.Header.Set("If-Modified-Since", previous.LastModified.UTC().Format(http.TimeFormat))
+	}
+}
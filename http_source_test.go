@@ -0,0 +1,151 @@
+package remoteconfig
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type HTTPSourceSuite struct {
+	suite.Suite
+}
+
+func TestHTTPSourceSuite(t *testing.T) {
+	suite.Run(t, new(HTTPSourceSuite))
+}
+
+func (s *HTTPSourceSuite) TestOpen() {
+	lastModified := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", `"abc"`)
+		w.Header().Set("X-Amz-Version-Id", "v1")
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Last-Modified", lastModified.Format(http.TimeFormat))
+		fmt.Fprint(w, "body")
+	}))
+	defer ts.Close()
+
+	location, _ := url.Parse(ts.URL)
+	body, metadata, err := (&HTTPSource{}).Open(context.Background(), location)
+	s.Nil(err)
+	defer body.Close()
+
+	data, err := ioutil.ReadAll(body)
+	s.Nil(err)
+	s.Equal("body", string(data))
+	s.Equal(&SourceMetadata{Version: "v1", ETag: `"abc"`, LastModified: lastModified, ContentType: "application/json"}, metadata)
+}
+
+func (s *HTTPSourceSuite) TestOpenErrorNotOK() {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer ts.Close()
+
+	location, _ := url.Parse(ts.URL)
+	body, metadata, err := (&HTTPSource{}).Open(context.Background(), location)
+	s.Nil(body)
+	s.Nil(metadata)
+	s.EqualError(err, fmt.Sprintf("Request to '%s' returned non-200 OK status '500: Internal Server Error'", ts.URL))
+}
+
+func (s *HTTPSourceSuite) TestOpenClient() {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "body")
+	}))
+	defer ts.Close()
+
+	client := &http.Client{Transport: &countingTransport{}}
+	location, _ := url.Parse(ts.URL)
+	body, _, err := (&HTTPSource{Client: client}).Open(context.Background(), location)
+	s.Nil(err)
+	body.Close()
+	s.Equal(1, client.Transport.(*countingTransport).count)
+}
+
+func (s *HTTPSourceSuite) TestOpenHeaders() {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.Equal("Bearer token", r.Header.Get("Authorization"))
+		s.Equal("service/1.0", r.Header.Get("User-Agent"))
+		fmt.Fprint(w, "body")
+	}))
+	defer ts.Close()
+
+	source := &HTTPSource{Header: http.Header{"Authorization": []string{"Bearer token"}}, UserAgent: "service/1.0"}
+	location, _ := url.Parse(ts.URL)
+	body, _, err := source.Open(context.Background(), location)
+	s.Nil(err)
+	body.Close()
+}
+
+func (s *HTTPSourceSuite) TestWithOptions() {
+	client := &http.Client{}
+	source := &HTTPSource{Header: http.Header{"X-One": []string{"1"}, "X-Two": []string{"2"}}, UserAgent: "default"}
+
+	withOptions := source.withOptions(newLoadOptions([]LoadOption{WithHTTPClient(client), WithHeader("X-Two", "b"), WithUserAgent("service/1.0")}))
+	s.True(client == withOptions.Client)
+	s.Equal(http.Header{"X-One": []string{"1"}, "X-Two": []string{"b"}}, withOptions.Header)
+	s.Equal("service/1.0", withOptions.UserAgent)
+
+	// The registered source is left untouched
+	s.Nil(source.Client)
+	s.Equal("2", source.Header.Get("X-Two"))
+	s.Equal("default", source.UserAgent)
+}
+
+func (s *HTTPSourceSuite) TestParseRetryAfter() {
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	s.Equal(time.Duration(0), parseRetryAfter("", now))
+	s.Equal(5*time.Second, parseRetryAfter("5", now))
+	s.Equal(time.Minute, parseRetryAfter(now.Add(time.Minute).Format(http.TimeFormat), now))
+	s.Equal(time.Duration(0), parseRetryAfter("invalid", now))
+}
+
+type countingTransport struct {
+	count int
+}
+
+func (c *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	c.count++
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func (s *HTTPSourceSuite) TestOpenIfModified() {
+	lastModified := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.Equal(`"abc"`, r.Header.Get("If-None-Match"))
+		s.Equal("Thu, 02 Jan 2020 03:04:05 GMT", r.Header.Get("If-Modified-Since"))
+		w.WriteHeader(http.StatusNotModified)
+	}))
+	defer ts.Close()
+
+	location, _ := url.Parse(ts.URL)
+	previous := &SourceMetadata{ETag: `"abc"`, LastModified: lastModified.In(time.FixedZone("EST", -5*60*60))}
+	body, metadata, err := (&HTTPSource{}).OpenIfModified(context.Background(), location, previous)
+	s.Nil(body)
+	s.Nil(metadata)
+	s.Equal(ErrNotModified, err)
+}
+
+func (s *HTTPSourceSuite) TestOpenIfModifiedNoPrevious() {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.Equal("", r.Header.Get("If-None-Match"))
+		s.Equal("", r.Header.Get("If-Modified-Since"))
+		fmt.Fprint(w, "body")
+	}))
+	defer ts.Close()
+
+	location, _ := url.Parse(ts.URL)
+	for _, previous := range []*SourceMetadata{nil, {}} {
+		body, _, err := (&HTTPSource{}).OpenIfModified(context.Background(), location, previous)
+		s.Nil(err)
+		body.Close()
+	}
+}
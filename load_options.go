@@ -0,0 +1,107 @@
+package remoteconfig
+
+import (
+	"net/http"
+	"net/url"
+)
+
+// Configures how a config is loaded, see LoadConfigFromURLWithOptions and Load.
+type LoadOption func(*loadOptions)
+
+type loadOptions struct {
+	client    *http.Client
+	header    http.Header
+	userAgent string
+	retry     *RetryPolicy
+	format    Format
+
+	allValidationErrors bool
+	strictDecoding      bool
+	unknownKeyWarnings  func(paths []string)
+}
+
+func newLoadOptions(opts []LoadOption) *loadOptions {
+	o := &loadOptions{
+		header: http.Header{},
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// Decodes the document in the given format, instead of detecting it from the Content-Type or file extension.
+func WithFormat(format Format) LoadOption {
+	return func(o *loadOptions) {
+		o.format = format
+	}
+}
+
+// Validates the whole config and returns every problem found as ValidationErrors,
+// instead of stopping at the first one.
+func WithAllValidationErrors() LoadOption {
+	return func(o *loadOptions) {
+		o.allValidationErrors = true
+	}
+}
+
+// Rejects documents with keys that don't match a field of the config struct, i.e. a misspelt "tabel_name".
+func WithStrictDecoding() LoadOption {
+	return func(o *loadOptions) {
+		o.strictDecoding = true
+	}
+}
+
+// Calls fn with the sorted paths of keys that don't match a field of the config struct, i.e. dynamodb_table.tabel_name,
+// so they can be logged or alerted on. The config is still loaded, unless WithStrictDecoding is also set.
+func WithUnknownKeyWarnings(fn func(paths []string)) LoadOption {
+	return func(o *loadOptions) {
+		o.unknownKeyWarnings = fn
+	}
+}
+
+// Uses the client for HTTP requests instead of http.DefaultClient,
+// i.e. for custom TLS roots, proxies, client certificates or tracing round trippers.
+func WithHTTPClient(client *http.Client) LoadOption {
+	return func(o *loadOptions) {
+		o.client = client
+	}
+}
+
+// Adds a header to HTTP requests.
+func WithHeader(key string, value string) LoadOption {
+	return func(o *loadOptions) {
+		o.header.Add(key, value)
+	}
+}
+
+// Sets a bearer token Authorization header on HTTP requests.
+func WithBearerToken(token string) LoadOption {
+	return func(o *loadOptions) {
+		o.header.Set("Authorization", "Bearer "+token)
+	}
+}
+
+// Sets a basic auth Authorization header on HTTP requests.
+func WithBasicAuth(username string, password string) LoadOption {
+	return func(o *loadOptions) {
+		req := http.Request{Header: http.Header{}}
+		req.SetBasicAuth(username, password)
+		o.header.Set("Authorization", req.Header.Get("Authorization"))
+	}
+}
+
+// Sets the User-Agent header on HTTP requests.
+func WithUserAgent(userAgent string) LoadOption {
+	return func(o *loadOptions) {
+		o.userAgent = userAgent
+	}
+}
+
+func (o *loadOptions) detectFormat(metadata *SourceMetadata, location *url.URL) Format {
+	contentType := ""
+	if metadata != nil {
+		contentType = metadata.ContentType
+	}
+	return detectFormat(o.format, contentType, location.Path)
+}
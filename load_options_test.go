@@ -0,0 +1,68 @@
+package remoteconfig
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type LoadOptionsSuite struct {
+	suite.Suite
+}
+
+func TestLoadOptionsSuite(t *testing.T) {
+	suite.Run(t, new(LoadOptionsSuite))
+}
+
+func (s *LoadOptionsSuite) TestNewLoadOptionsDefaults() {
+	o := newLoadOptions(nil)
+	s.Nil(o.client)
+	s.Empty(o.header)
+	s.Empty(o.userAgent)
+}
+
+func (s *LoadOptionsSuite) TestWithHTTPClient() {
+	client := &http.Client{}
+	o := newLoadOptions([]LoadOption{WithHTTPClient(client)})
+	s.True(client == o.client)
+}
+
+func (s *LoadOptionsSuite) TestWithHeader() {
+	o := newLoadOptions([]LoadOption{WithHeader("X-Test", "a"), WithHeader("X-Test", "b")})
+	s.Equal([]string{"a", "b"}, o.header["X-Test"])
+}
+
+func (s *LoadOptionsSuite) TestWithBearerToken() {
+	o := newLoadOptions([]LoadOption{WithBearerToken("token")})
+	s.Equal("Bearer token", o.header.Get("Authorization"))
+}
+
+func (s *LoadOptionsSuite) TestWithBasicAuth() {
+	o := newLoadOptions([]LoadOption{WithBasicAuth("user", "pass")})
+	s.Equal("Basic dXNlcjpwYXNz", o.header.Get("Authorization"))
+}
+
+func (s *LoadOptionsSuite) TestWithUserAgent() {
+	o := newLoadOptions([]LoadOption{WithUserAgent("service/1.0")})
+	s.Equal("service/1.0", o.userAgent)
+}
+
+func (s *LoadOptionsSuite) TestWithAllValidationErrors() {
+	s.False(newLoadOptions(nil).allValidationErrors)
+	s.True(newLoadOptions([]LoadOption{WithAllValidationErrors()}).allValidationErrors)
+}
+
+func (s *LoadOptionsSuite) TestWithStrictDecoding() {
+	s.False(newLoadOptions(nil).strictDecoding)
+	s.True(newLoadOptions([]LoadOption{WithStrictDecoding()}).strictDecoding)
+}
+
+func (s *LoadOptionsSuite) TestWithUnknownKeyWarnings() {
+	s.Nil(newLoadOptions(nil).unknownKeyWarnings)
+
+	warned := []string{}
+	o := newLoadOptions([]LoadOption{WithUnknownKeyWarnings(func(paths []string) { warned = paths })})
+	o.unknownKeyWarnings([]string{"a"})
+	s.Equal([]string{"a"}, warned)
+}
@@ -0,0 +1,70 @@
+package remoteconfig
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// Result of loading a config with a Loader.
+type LoadResult struct {
+	// Metadata of the document the config was loaded from, i.e. its ETag, Last-Modified or S3 version
+	Metadata *SourceMetadata
+	// The document hasn't changed since the last successful load, so nothing was decoded or validated
+	// and the config struct was left alone
+	Unchanged bool
+}
+
+// Loads a config from a URL like Load, remembering the ETag and Last-Modified of the last successful load.
+// Later loads send If-None-Match and If-Modified-Since, through sources that implement ConditionalSource,
+// so an unchanged document isn't downloaded or decoded again. Safe for concurrent use.
+type Loader struct {
+	configURL string
+	opts      []LoadOption
+
+	mutex    sync.Mutex
+	metadata *SourceMetadata
+}
+
+// Creates a Loader for the config at a URL, with the load options used for every load.
+func NewLoader(configURL string, opts ...LoadOption) *Loader {
+	return &Loader{
+		configURL: configURL,
+		opts:      opts,
+	}
+}
+
+// Loads the config into the config struct, unless the document hasn't changed since the last successful load.
+// A document that fails to decode or validate isn't remembered, so the next load fetches it again.
+func (l *Loader) Load(ctx context.Context, configStruct interface{}) (*LoadResult, error) {
+	options := newLoadOptions(l.opts)
+	source, location, err := getURLSource(l.configURL, options)
+	if err != nil {
+		return nil, err
+	}
+
+	previous := l.Metadata()
+	metadata, err := loadFromSourceIfModified(ctx, source, location, previous, configStruct, options)
+	if errors.Is(err, ErrNotModified) {
+		return &LoadResult{Metadata: previous, Unchanged: true}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if metadata == nil {
+		metadata = &SourceMetadata{}
+	}
+	l.mutex.Lock()
+	l.metadata = metadata
+	l.mutex.Unlock()
+
+	return &LoadResult{Metadata: metadata}, nil
+}
+
+// Returns the metadata of the last successful load, or nil before the first.
+func (l *Loader) Metadata() *SourceMetadata {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return l.metadata
+}
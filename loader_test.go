@@ -0,0 +1,177 @@
+package remoteconfig
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type LoaderSuite struct {
+	suite.Suite
+	server *httptest.Server
+
+	mutex        sync.Mutex
+	document     string
+	etag         string
+	lastModified time.Time
+	requests     int
+	notModified  int
+}
+
+func TestLoaderSuite(t *testing.T) {
+	suite.Run(t, new(LoaderSuite))
+}
+
+func (s *LoaderSuite) SetupTest() {
+	s.document = validConfigJSON
+	s.etag = `"v1"`
+	s.lastModified = time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	s.requests = 0
+	s.notModified = 0
+	s.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+		s.requests++
+
+		if s.etag != "" && r.Header.Get("If-None-Match") == s.etag {
+			s.notModified++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		if s.etag != "" {
+			w.Header().Set("ETag", s.etag)
+		}
+		w.Header().Set("Last-Modified", s.lastModified.Format(http.TimeFormat))
+		w.Header().Set("X-Amz-Version-Id", strings.Trim(s.etag, `"`))
+		fmt.Fprint(w, s.document)
+	}))
+}
+
+func (s *LoaderSuite) TearDownTest() {
+	s.server.Close()
+}
+
+func (s *LoaderSuite) serve(document string, etag string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.document = document
+	s.etag = etag
+}
+
+func (s *LoaderSuite) TestLoad() {
+	l := NewLoader(s.server.URL + "/config.json")
+	s.Nil(l.Metadata())
+
+	c := &SampleConfig{}
+	result, err := l.Load(context.Background(), c)
+	s.Nil(err)
+	s.False(result.Unchanged)
+	s.Equal(`"v1"`, result.Metadata.ETag)
+	s.Equal("v1", result.Metadata.Version)
+	s.True(s.lastModified.Equal(result.Metadata.LastModified))
+	s.Equal(result.Metadata, l.Metadata())
+	s.Equal("testStr", c.Str)
+}
+
+func (s *LoaderSuite) TestLoadUnchanged() {
+	l := NewLoader(s.server.URL + "/config.json")
+	_, err := l.Load(context.Background(), &SampleConfig{})
+	s.Nil(err)
+
+	// Nothing is decoded into the config struct when the document is unchanged
+	c := &SampleConfig{}
+	result, err := l.Load(context.Background(), c)
+	s.Nil(err)
+	s.True(result.Unchanged)
+	s.Equal(`"v1"`, result.Metadata.ETag)
+	s.Equal(&SampleConfig{}, c)
+	s.Equal(1, s.notModified)
+
+	s.serve(strings.Replace(validConfigJSON, `"testStr"`, `"otherStr"`, 1), `"v2"`)
+	result, err = l.Load(context.Background(), c)
+	s.Nil(err)
+	s.False(result.Unchanged)
+	s.Equal(`"v2"`, result.Metadata.ETag)
+	s.Equal("otherStr", c.Str)
+}
+
+func (s *LoaderSuite) TestLoadInvalidNotRemembered() {
+	l := NewLoader(s.server.URL + "/config.json")
+	_, err := l.Load(context.Background(), &SampleConfig{})
+	s.Nil(err)
+
+	s.serve(`{"str": "invalid"}`, `"v2"`)
+	result, err := l.Load(context.Background(), &SampleConfig{})
+	s.Nil(result)
+	s.True(errors.Is(err, ErrValidation))
+	s.Equal(`"v1"`, l.Metadata().ETag)
+
+	// The invalid document is fetched and fails again, rather than being treated as unchanged
+	_, err = l.Load(context.Background(), &SampleConfig{})
+	s.True(errors.Is(err, ErrValidation))
+	s.Equal(0, s.notModified)
+}
+
+func (s *LoaderSuite) TestLoadWithRetry() {
+	l := NewLoader(s.server.URL+"/config.json", WithRetry(RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond}))
+	_, err := l.Load(context.Background(), &SampleConfig{})
+	s.Nil(err)
+
+	result, err := l.Load(context.Background(), &SampleConfig{})
+	s.Nil(err)
+	s.True(result.Unchanged)
+	s.Equal(2, s.requests)
+}
+
+func (s *LoaderSuite) TestLoadFile() {
+	dir, err := ioutil.TempDir("", "remoteconfig")
+	s.Nil(err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "config.json")
+	s.Nil(ioutil.WriteFile(path, []byte(validConfigJSON), 0644))
+
+	l := NewLoader("file://" + path)
+	_, err = l.Load(context.Background(), &SampleConfig{})
+	s.Nil(err)
+
+	result, err := l.Load(context.Background(), &SampleConfig{})
+	s.Nil(err)
+	s.True(result.Unchanged)
+}
+
+func (s *LoaderSuite) TestLoadNonConditionalSource() {
+	RegisterSource("loadertest", &memorySource{documents: map[string]string{"config.json": validConfigJSON}})
+	defer func() {
+		sourcesMutex.Lock()
+		delete(sources, "loadertest")
+		sourcesMutex.Unlock()
+	}()
+
+	l := NewLoader("loadertest://config.json")
+	for i := 0; i < 2; i++ {
+		c := &SampleConfig{}
+		result, err := l.Load(context.Background(), c)
+		s.Nil(err)
+		s.False(result.Unchanged)
+		s.Equal("1", result.Metadata.Version)
+		s.Equal("testStr", c.Str)
+	}
+}
+
+func (s *LoaderSuite) TestLoadErrorURL() {
+	_, err := NewLoader("unknown://config.json").Load(context.Background(), &SampleConfig{})
+	s.EqualError(err, "No source registered for scheme 'unknown'")
+}
@@ -0,0 +1,106 @@
+package remoteconfig
+
+import (
+	"reflect"
+	"runtime"
+	"sync"
+)
+
+// Plans built for struct types, keyed by reflect.Type
+var structPlans sync.Map
+
+// What the validator and defaults need to know about a struct type, built once with reflection and cached,
+// so repeated validations only walk values.
+type structPlan struct {
+	// The struct, or a pointer to it, implements Validater
+	validater bool
+	// Index in fields of the embedded field the Validate method is promoted from, -1 when the struct declares its own
+	validateFrom int
+	// Exported and embedded fields, unexported fields can't be read through reflection
+	fields []*fieldPlan
+}
+
+type fieldPlan struct {
+	index     int
+	name      string
+	jsonName  string
+	anonymous bool
+	tag       fieldTag
+	// The field is an embedded struct or struct pointer whose fields are promoted, see isEmbeddedStruct
+	embedded bool
+	// The field's type, or a pointer to it, implements Validater
+	validater bool
+}
+
+// Returns the plan for a struct type, building it on first use.
+func getStructPlan(t reflect.Type) *structPlan {
+	if plan, ok := structPlans.Load(t); ok {
+		return plan.(*structPlan)
+	}
+	plan, _ := structPlans.LoadOrStore(t, buildStructPlan(t))
+	return plan.(*structPlan)
+}
+
+func buildStructPlan(t reflect.Type) *structPlan {
+	plan := &structPlan{
+		validater:    implementsValidater(t),
+		validateFrom: -1,
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" && !field.Anonymous {
+			continue
+		}
+
+		tag := parseFieldTag(field)
+		for j := range tag.Constraints {
+			tag.Constraints[j].compile()
+		}
+		for j := range tag.CrossField {
+			tag.CrossField[j].compile()
+		}
+
+		plan.fields = append(plan.fields, &fieldPlan{
+			index:     i,
+			name:      field.Name,
+			jsonName:  jsonFieldName(field),
+			anonymous: field.Anonymous,
+			tag:       tag,
+			embedded:  isEmbeddedStruct(field),
+			validater: implementsValidater(field.Type),
+		})
+	}
+
+	// A promoted Validate comes from the one embedded field at the shallowest depth that has it,
+	// methods are only promoted through fields that have them themselves
+	if plan.validater && !declaresValidate(t) {
+		for i, field := range plan.fields {
+			if field.anonymous && field.validater {
+				plan.validateFrom = i
+				break
+			}
+		}
+	}
+
+	return plan
+}
+
+// Returns true when a type declares its own Validate method, rather than having it promoted from an embedded field.
+// Reflection doesn't tell the two apart, but promoted methods are compiler generated wrappers without a source file.
+func declaresValidate(t reflect.Type) bool {
+	m, ok := t.MethodByName("Validate")
+	if !ok {
+		// Value receiver methods are also wrapped for the pointer type, so it's only checked without one
+		if m, ok = reflect.PtrTo(t).MethodByName("Validate"); !ok {
+			return false
+		}
+	}
+
+	fn := runtime.FuncForPC(m.Func.Pointer())
+	if fn == nil {
+		return true
+	}
+	file, _ := fn.FileLine(fn.Entry())
+	return file != "<autogenerated>"
+}
@@ -0,0 +1,161 @@
+package remoteconfig
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type PlanSuite struct {
+	suite.Suite
+}
+
+func TestPlanSuite(t *testing.T) {
+	suite.Run(t, new(PlanSuite))
+}
+
+type PlanConfig struct {
+	EmbeddedConfig
+	Name     *string   `json:"name" remoteconfig:"regex=^[a-z]+$"`
+	Count    int       `json:"count" remoteconfig:"optional,expr=self < 10"`
+	Region   AWSRegion `json:"region"`
+	internal string
+}
+
+func (s *PlanSuite) TestBuildStructPlan() {
+	plan := buildStructPlan(reflect.TypeOf(PlanConfig{}))
+	s.False(plan.validater)
+	s.Len(plan.fields, 4)
+
+	s.Equal("EmbeddedConfig", plan.fields[0].name)
+	s.True(plan.fields[0].anonymous)
+	s.True(plan.fields[0].embedded)
+
+	s.Equal(1, plan.fields[1].index)
+	s.Equal("name", plan.fields[1].jsonName)
+	s.NotNil(plan.fields[1].tag.Constraints[0].regex)
+
+	s.True(plan.fields[2].tag.Optional)
+	s.NotNil(plan.fields[2].tag.CrossField[0].expr)
+
+	s.Equal("Region", plan.fields[3].name)
+	s.True(plan.fields[3].validater)
+
+	s.True(buildStructPlan(reflect.TypeOf(StorageConfig{})).validater)
+}
+
+func (s *PlanSuite) TestBuildStructPlanValidateFrom() {
+	s.Equal(-1, buildStructPlan(reflect.TypeOf(PlanConfig{})).validateFrom)
+	s.Equal(-1, buildStructPlan(reflect.TypeOf(EmbeddedValidaterConfig{})).validateFrom)
+	s.Equal(0, buildStructPlan(reflect.TypeOf(EmbeddedValidaterPointerConfig{})).validateFrom)
+	s.Equal(-1, buildStructPlan(reflect.TypeOf(OwnValidaterEmbeddedConfig{})).validateFrom)
+	s.Equal(-1, buildStructPlan(reflect.TypeOf(AmbiguousValidaterConfig{})).validateFrom)
+
+	s.True(declaresValidate(reflect.TypeOf(EmbeddedValidaterConfig{})))
+	s.True(declaresValidate(reflect.TypeOf(StorageConfig{})))
+	s.True(declaresValidate(reflect.TypeOf(PointerValidaterConfig{})))
+	s.False(declaresValidate(reflect.TypeOf(EmbeddedValidaterPointerConfig{})))
+}
+
+func (s *PlanSuite) TestBuildStructPlanInvalidParams() {
+	type InvalidConfig struct {
+		Name  string `remoteconfig:"regex=["`
+		Count int    `remoteconfig:"expr=self >"`
+	}
+
+	// Invalid parameters are left for the checks to report
+	plan := buildStructPlan(reflect.TypeOf(InvalidConfig{}))
+	s.Nil(plan.fields[0].tag.Constraints[0].regex)
+	s.Nil(plan.fields[1].tag.CrossField[0].expr)
+}
+
+func (s *PlanSuite) TestGetStructPlanCached() {
+	t := reflect.TypeOf(PlanConfig{})
+	plan := getStructPlan(t)
+	s.True(plan == getStructPlan(t))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.True(plan == getStructPlan(t))
+		}()
+	}
+	wg.Wait()
+}
+
+func resetStructPlans() {
+	structPlans.Range(func(key, value interface{}) bool {
+		structPlans.Delete(key)
+		return true
+	})
+}
+
+func BenchmarkValidateConfigWithReflection(b *testing.B) {
+	c := (&RemoteConfigSuite{}).buildValidSampleConfig()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := validateConfigWithReflection(c); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// Rebuilds the plans on every validation, as the validator did before plans were cached
+func BenchmarkValidateConfigWithReflectionUncached(b *testing.B) {
+	c := (&RemoteConfigSuite{}).buildValidSampleConfig()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		resetStructPlans()
+		if err := validateConfigWithReflection(c); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkReadJSONValidate(b *testing.B) {
+	data := []byte(validConfigJSON)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := ReadJSONValidate(bytes.NewReader(data), &SampleConfig{}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+type PointerValidaterConfig struct {
+	Name string `json:"name"`
+}
+
+func (c *PointerValidaterConfig) Validate() error {
+	if c.Name == "invalid" {
+		return errors.New("Name is invalid")
+	}
+	return nil
+}
+
+func (s *PlanSuite) TestImplementsValidater() {
+	s.True(implementsValidater(reflect.TypeOf(PointerValidaterConfig{})))
+	s.True(implementsValidater(reflect.TypeOf(&PointerValidaterConfig{})))
+	s.True(implementsValidater(reflect.TypeOf(StorageConfig{})))
+	s.True(implementsValidater(reflect.TypeOf(&StorageConfig{})))
+	s.False(implementsValidater(reflect.TypeOf(SQSQueueConfig{})))
+	s.False(implementsValidater(reflect.TypeOf((**PointerValidaterConfig)(nil))))
+}
+
+func (s *PlanSuite) TestAsValidater() {
+	c := PointerValidaterConfig{Name: "invalid"}
+	s.EqualError(asValidater(reflect.ValueOf(&c).Elem()).Validate(), "Name is invalid")
+
+	// Values that aren't addressable, i.e. map values, are validated on a copy
+	m := map[string]PointerValidaterConfig{"one": c}
+	s.EqualError(asValidater(reflect.ValueOf(m).MapIndex(reflect.ValueOf("one"))).Validate(), "Name is invalid")
+}
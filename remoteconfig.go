@@ -1,12 +1,14 @@
 package remoteconfig
 
 import (
-	"encoding/json"
+	"bytes"
+	"context"
 	"fmt"
 	"io"
-	"net/http"
+	"io/ioutil"
+	"net/url"
 	"reflect"
-	"strings"
+	"sort"
 )
 
 const (
@@ -14,97 +16,398 @@ const (
 	DEFAULT_S3_ENDPOINT string = ""
 )
 
+// Implemented by config types with their own validation, with either a value or a pointer receiver.
 type Validater interface {
 	Validate() error
 }
 
-// Downloads a configuration JSON file from S3.
+// Downloads a configuration file from an HTTP/HTTPS URL, i.e. a signed S3 URL.
 // Parses it to a particular struct type and runs a validation.
-// URL should be of the format s3://bucket/path/file.json
+// The format is picked from the Content-Type header or the file extension, defaulting to JSON.
+// For s3://bucket/path/file.json URLs use LoadConfigFromS3.
 func LoadConfigFromURL(configURL string, configStruct interface{}) error {
-	resp, err := http.Get(configURL)
+	return LoadConfigFromURLWithContext(context.Background(), configURL, configStruct)
+}
+
+// Same as LoadConfigFromURL, the download and decode stop when the context is done.
+// A TimeoutError is returned when the deadline is exceeded.
+func LoadConfigFromURLWithContext(ctx context.Context, configURL string, configStruct interface{}) error {
+	return LoadConfigFromURLWithOptions(ctx, configURL, configStruct)
+}
+
+// Same as LoadConfigFromURLWithContext, with options for the HTTP client, request headers, user agent, retries and format.
+func LoadConfigFromURLWithOptions(ctx context.Context, configURL string, configStruct interface{}, opts ...LoadOption) error {
+	location, err := url.Parse(configURL)
 	if err != nil {
 		return err
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("Request to '%s' returned non-200 OK status '%d: %s'", configURL, resp.StatusCode, http.StatusText(resp.StatusCode))
-	}
-
-	return ReadJSONValidate(resp.Body, configStruct)
+	options := newLoadOptions(opts)
+	return loadFromSource(ctx, (&HTTPSource{}).withOptions(options), location, configStruct, options)
 }
 
 // Downloads JSON from a URL, decodes it and then validates.
 func ReadJSONValidate(cfgReader io.Reader, configStruct interface{}) error {
-	// Do a streaming JSON decode
-	dec := json.NewDecoder(cfgReader)
-	if err := dec.Decode(configStruct); err != nil {
-		return fmt.Errorf("Failed to decode JSON, with error, %s", err.Error())
+	return ReadJSONValidateWithContext(context.Background(), cfgReader, configStruct)
+}
+
+// Same as ReadJSONValidate, reading stops when the context is done.
+// A TimeoutError is returned when the deadline is exceeded.
+func ReadJSONValidateWithContext(ctx context.Context, cfgReader io.Reader, configStruct interface{}) error {
+	return ReadValidateWithContext(ctx, cfgReader, FORMAT_JSON, configStruct)
+}
+
+// Decodes YAML, then validates.
+// YAML keys are matched with the yaml struct tags, remoteconfig tags and UnmarshalText methods work as they do for JSON.
+func ReadYAMLValidate(cfgReader io.Reader, configStruct interface{}) error {
+	return ReadYAMLValidateWithContext(context.Background(), cfgReader, configStruct)
+}
+
+// Same as ReadYAMLValidate, reading stops when the context is done.
+// A TimeoutError is returned when the deadline is exceeded.
+func ReadYAMLValidateWithContext(ctx context.Context, cfgReader io.Reader, configStruct interface{}) error {
+	return ReadValidateWithContext(ctx, cfgReader, FORMAT_YAML, configStruct)
+}
+
+// Decodes a document in the given format, then validates.
+// Accepts the decoding and validation load options, i.e. WithAllValidationErrors.
+func ReadValidate(cfgReader io.Reader, format Format, configStruct interface{}, opts ...LoadOption) error {
+	return ReadValidateWithContext(context.Background(), cfgReader, format, configStruct, opts...)
+}
+
+// Same as ReadValidate, reading stops when the context is done.
+// A TimeoutError is returned when the deadline is exceeded.
+// A read blocked on the reader is abandoned rather than interrupted, close the reader to release it.
+func ReadValidateWithContext(ctx context.Context, cfgReader io.Reader, format Format, configStruct interface{}, opts ...LoadOption) error {
+	return readValidate(ctx, cfgReader, format, configStruct, newLoadOptions(opts))
+}
+
+func readValidate(ctx context.Context, cfgReader io.Reader, format Format, configStruct interface{}, options *loadOptions) error {
+	if err := format.Validate(); err != nil {
+		return err
 	}
 
-	// Run validation on the config
-	if err := validateConfigWithReflection(configStruct); err != nil {
+	cfgReader = &contextReader{ctx: ctx, reader: cfgReader}
+
+	// Unknown keys are found on a generic decode of the document, so it's read up front
+	if options.unknownKeyWarnings != nil {
+		data, err := ioutil.ReadAll(cfgReader)
+		if err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return wrapContextError(ctxErr)
+			}
+			return err
+		}
+		// A document that can't be decoded is reported by decodeConfig below
+		if keys, err := findUnknownKeys(data, format, configStruct); err == nil && len(keys) > 0 {
+			options.unknownKeyWarnings(keys)
+		}
+		cfgReader = bytes.NewReader(data)
+	}
+
+	if err := decodeConfig(cfgReader, format, configStruct, options.strictDecoding); err != nil {
+		// A read interrupted by the context isn't a problem with the document itself
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return wrapContextError(ctxErr)
+		}
 		return err
 	}
 
-	return nil
+	// Fill in default values before the required fields are checked
+	if err := applyDefaultsWithReflection(configStruct); err != nil {
+		return err
+	}
+
+	// Run validation on the config
+	if options.allValidationErrors {
+		return validateAllConfigWithReflection(configStruct)
+	}
+	return validateConfigWithReflection(configStruct)
 }
 
 func isNilFixed(v reflect.Value) bool {
 	switch v.Kind() {
-	case reflect.Ptr, reflect.Map, reflect.Array, reflect.Chan, reflect.Slice:
+	case reflect.Ptr, reflect.Map, reflect.Chan, reflect.Slice:
 		//use of IsNil method
 		return v.IsNil()
 	}
 	return false
 }
 
-// Validates a configuration struct.
+// Returns true for an empty string or a pointer to one.
+func isEmptyString(v reflect.Value) bool {
+	if s, ok := v.Interface().(*string); ok {
+		return *s == ""
+	}
+	if s, ok := v.Interface().(string); ok {
+		return s == ""
+	}
+	return false
+}
+
+// Returns the keys of a map in a stable order, so validation errors are reported consistently.
+func sortedMapKeys(v reflect.Value) []reflect.Value {
+	keys := v.MapKeys()
+	sort.Slice(keys, func(i, j int) bool {
+		return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+	})
+	return keys
+}
+
+// Gets a refection Type value for the Validater interface
+var validaterType = reflect.TypeOf((*Validater)(nil)).Elem()
+
+// Returns true when a type, or a pointer to it, has a Validate method.
+func implementsValidater(t reflect.Type) bool {
+	return t.Implements(validaterType) || (t.Kind() != reflect.Ptr && reflect.PtrTo(t).Implements(validaterType))
+}
+
+// Returns false when a value's Validate method is promoted through a nil embedded pointer, so calling it would panic.
+func validateReachable(v reflect.Value) bool {
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return false
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return true
+	}
+
+	plan := getStructPlan(v.Type())
+	if plan.validateFrom < 0 {
+		return true
+	}
+	return validateReachable(v.Field(plan.fields[plan.validateFrom].index))
+}
+
+// Returns the value as an interface, or nil when it was read through an unexported field.
+func interfaceOrNil(v reflect.Value) interface{} {
+	if !v.CanInterface() {
+		return nil
+	}
+	return v.Interface()
+}
+
+// Returns a value as a Validater, taking its address when Validate has a pointer receiver.
+// The value's type must satisfy implementsValidater.
+func asValidater(v reflect.Value) Validater {
+	if validater, ok := v.Interface().(Validater); ok {
+		return validater
+	}
+	return addressable(v).Addr().Interface().(Validater)
+}
+
+// Validates a configuration struct, returning the first problem found as a *ValidationError.
 // Uses reflection to determine and call the correct Validation methods for each type.
 func validateConfigWithReflection(c interface{}) error {
-	valueElem := reflect.ValueOf(c).Elem()
-	typeElem := reflect.TypeOf(c).Elem()
+	if errs := validateConfig(c, false); len(errs) > 0 {
+		return errs[0]
+	}
+	return nil
+}
+
+// Validates a configuration struct, returning every problem found as ValidationErrors.
+func validateAllConfigWithReflection(c interface{}) error {
+	if errs := validateConfig(c, true); len(errs) > 0 {
+		return ValidationErrors(errs)
+	}
+	return nil
+}
+
+// Walks a configuration struct, stopping at the first problem unless collectAll is set.
+func validateConfig(c interface{}, collectAll bool) []error {
+	v := &configValidator{collectAll: collectAll}
+	v.validateStruct(reflect.ValueOf(c), "", "")
+	return v.errs
+}
 
-	// Gets a refection Type value for the Validater interface
-	validaterType := reflect.TypeOf((*Validater)(nil)).Elem()
+// State of a walk over a configuration struct.
+type configValidator struct {
+	collectAll bool
+	errs       []error
+}
+
+// Records a problem, returns true when the walk should stop
+func (v *configValidator) report(path string, jsonPath string, rule string, value interface{}, err error) bool {
+	v.errs = append(v.errs, &ValidationError{
+		Path:     path,
+		JSONPath: jsonPath,
+		Rule:     rule,
+		Value:    value,
+		Err:      err,
+	})
+	return !v.collectAll
+}
+
+// Returns the path a struct's own problems are reported under, the config itself is reported by its type name.
+func structPath(path string, t reflect.Type) string {
+	if path == "" {
+		return t.Name()
+	}
+	return path
+}
+
+// Records a failed constraint rule, returns true when the walk should stop
+func (v *configValidator) reportConstraint(path string, jsonPath string, c fieldConstraint, value interface{}, err error) bool {
+	v.errs = append(v.errs, &ValidationError{
+		Path:     path,
+		JSONPath: jsonPath,
+		Rule:     c.Rule,
+		Param:    c.Param,
+		Value:    value,
+		Err:      err,
+	})
+	return !v.collectAll
+}
+
+// Validates the struct a pointer points to, path and jsonPath locate the struct within the config.
+// Returns true when the walk should stop.
+func (v *configValidator) validateStruct(ptr reflect.Value, path string, jsonPath string) bool {
+	valueElem := ptr.Elem()
+	typeElem := valueElem.Type()
+	plan := getStructPlan(typeElem)
 
-	// If the Validater interface is implemented, call the Validate method
-	if typeElem.Implements(validaterType) {
-		if err := valueElem.Interface().(Validater).Validate(); err != nil {
-			return fmt.Errorf("Validater Field: %s, failed to validate with error, %s", typeElem.Name(), err)
+	// If the Validater interface is implemented, call the Validate method,
+	// unless it's promoted through a nil embedded pointer, which is reported with the fields
+	covered := -1
+	if plan.validater && validateReachable(valueElem) {
+		covered = plan.validateFrom
+		if err := ptr.Interface().(Validater).Validate(); err != nil {
+			if v.report(structPath(path, typeElem), jsonPath, VALIDATION_RULE_VALIDATE, ptr.Interface(), err) {
+				return true
+			}
+		}
+	}
+
+	// Check the rules registered for the type with RegisterRule
+	for _, e := range getRules(typeElem) {
+		if err := checkExpr(e, ptr, reflect.Value{}); err != nil {
+			if v.reportConstraint(structPath(path, typeElem), jsonPath, fieldConstraint{Rule: VALIDATION_RULE_EXPR, Param: e.String()}, ptr.Interface(), err) {
+				return true
+			}
 		}
 	}
 
-	for i := 0; i < valueElem.NumField(); i++ {
-		valueField := valueElem.Field(i)
-		typeField := typeElem.Field(i)
+	return v.validateFields(valueElem, valueElem, plan, path, jsonPath, covered)
+}
 
-		tags := typeField.Tag.Get("remoteconfig")
-		optional := strings.Contains(tags, "optional")
+// Validates the fields of a struct value, fields of embedded structs are promoted so they keep the struct's path.
+// parent is the outermost struct the fields are promoted to, cross-field rules resolve siblings on it.
+// covered is the index in the plan's fields of the embedded field whose Validate method was already called
+// as the struct's promoted one, -1 for none. Returns true when the walk should stop.
+func (v *configValidator) validateFields(parent reflect.Value, valueElem reflect.Value, plan *structPlan, path string, jsonPath string, covered int) bool {
+	for i, field := range plan.fields {
+		valueField := valueElem.Field(field.index)
+		tag := field.tag
+		optional := tag.Optional
 
-		if valueField.Kind() == reflect.Struct && typeField.Anonymous {
+		if field.embedded {
+			// The exported fields promoted from an unexported embedded struct are still validated,
+			// only the embedded value itself can't be read through reflection
+			embeddedPath := joinFieldPath(path, field.name)
+			if valueField.Kind() == reflect.Ptr {
+				if valueField.IsNil() {
+					if !optional && v.report(embeddedPath, jsonPath, VALIDATION_RULE_REQUIRED, interfaceOrNil(valueField), nil) {
+						return true
+					}
+					continue
+				}
+				valueField = valueField.Elem()
+			}
+
+			embeddedPlan := getStructPlan(valueField.Type())
+			embeddedCovered := -1
+			if i == covered {
+				embeddedCovered = embeddedPlan.validateFrom
+			} else if embeddedPlan.validater && valueField.CanInterface() && validateReachable(valueField) {
+				embeddedCovered = embeddedPlan.validateFrom
+				if err := asValidater(valueField).Validate(); err != nil {
+					if v.report(embeddedPath, jsonPath, VALIDATION_RULE_VALIDATE, valueField.Interface(), err) {
+						return true
+					}
+				}
+			}
+
+			if v.validateFields(parent, valueField, embeddedPlan, path, jsonPath, embeddedCovered) {
+				return true
+			}
+			continue
+		}
+
+		fieldPath := joinFieldPath(path, field.name)
+		fieldJSONPath := joinFieldPath(jsonPath, field.jsonName)
+
+		// A misplaced tag part would otherwise be taken as part of a regex or expr rule
+		tagFailed := false
+		for _, c := range append(append([]fieldConstraint{}, tag.Constraints...), tag.CrossField...) {
+			if c.err != nil {
+				tagFailed = true
+				if v.reportConstraint(fieldPath, fieldJSONPath, c, interfaceOrNil(valueField), c.err) {
+					return true
+				}
+			}
+		}
+		if tagFailed {
+			continue
+		}
+
+		// Check the cross-field rules from the tag, i.e. required_with=Endpoint
+		crossFieldFailed := false
+		for _, c := range tag.CrossField {
+			if err := checkCrossFieldConstraint(parent, valueField, c, path); err != nil {
+				crossFieldFailed = true
+				if v.reportConstraint(fieldPath, fieldJSONPath, c, valueField.Interface(), err) {
+					return true
+				}
+			}
+		}
+		if crossFieldFailed {
 			continue
 		}
 
 		if isNilFixed(valueField) && !optional {
-			return fmt.Errorf("Field: %s, not set", typeField.Name)
+			if v.report(fieldPath, fieldJSONPath, VALIDATION_RULE_REQUIRED, valueField.Interface(), nil) {
+				return true
+			}
+			continue
 		} else if isNilFixed(valueField) && optional {
 			continue
 		}
 
-		// Handle a slice type
-		if valueField.Kind() == reflect.Slice {
-			if valueField.Len() <= 0 {
-				return fmt.Errorf("Slice Field: %s, is empty", typeField.Name)
+		// Check the constraint rules from the tag, i.e. min=1. An optional field left at its zero value is skipped,
+		// as is an empty string which is reported as empty below.
+		if len(tag.Constraints) > 0 && !(optional && valueField.IsZero()) && !isEmptyString(valueField) {
+			failed := false
+			for _, c := range tag.Constraints {
+				if err := checkConstraint(valueField, c); err != nil {
+					failed = true
+					if v.reportConstraint(fieldPath, fieldJSONPath, c, valueField.Interface(), err) {
+						return true
+					}
+				}
 			}
-			for i := 0; i < valueField.Len(); i++ {
-				sliceValue := valueField.Index(i)
-				if sliceValue.Kind() != reflect.Ptr || sliceValue.IsNil() || sliceValue.Elem().Kind() != reflect.Struct {
-					continue
+			if failed {
+				continue
+			}
+		}
+
+		// Pointers to slices and maps are validated like the slice or map itself
+		if valueField.Kind() == reflect.Ptr && (valueField.Elem().Kind() == reflect.Slice || valueField.Elem().Kind() == reflect.Map) {
+			valueField = valueField.Elem()
+		}
+
+		// Handle a slice or array type
+		if valueField.Kind() == reflect.Slice || valueField.Kind() == reflect.Array {
+			if valueField.Kind() == reflect.Slice && valueField.Len() <= 0 {
+				if v.report(fieldPath, fieldJSONPath, VALIDATION_RULE_NOT_EMPTY, valueField.Interface(), nil) {
+					return true
 				}
-				if err := validateConfigWithReflection(sliceValue.Interface()); err != nil {
-					return err
+				continue
+			}
+			for i := 0; i < valueField.Len(); i++ {
+				if v.validateElement(valueField.Index(i), fmt.Sprintf("%s[%d]", fieldPath, i), fmt.Sprintf("%s[%d]", fieldJSONPath, i)) {
+					return true
 				}
 			}
 			continue
@@ -112,13 +415,10 @@ func validateConfigWithReflection(c interface{}) error {
 
 		// Handle a map type
 		if valueField.Kind() == reflect.Map {
-			for _, key := range valueField.MapKeys() {
-				mapValue := valueField.MapIndex(key)
-				if mapValue.Kind() != reflect.Ptr || mapValue.IsNil() || mapValue.Elem().Kind() != reflect.Struct {
-					continue
-				}
-				if err := validateConfigWithReflection(mapValue.Interface()); err != nil {
-					return fmt.Errorf("Sub field of %s with key '%s' failed to validated with error, %s", typeField.Name, key, err)
+			for _, key := range sortedMapKeys(valueField) {
+				keyName := fmt.Sprint(key.Interface())
+				if v.validateElement(valueField.MapIndex(key), fmt.Sprintf("%s[%q]", fieldPath, keyName), joinFieldPath(fieldJSONPath, keyName)) {
+					return true
 				}
 			}
 			continue
@@ -127,7 +427,9 @@ func validateConfigWithReflection(c interface{}) error {
 		// If this is a string pointer field, check that it isn't empty (unless optional)
 		if s, ok := valueField.Interface().(*string); ok {
 			if *s == "" {
-				return fmt.Errorf("String Field: %s, contains an empty string", typeField.Name)
+				if v.report(fieldPath, fieldJSONPath, VALIDATION_RULE_NOT_EMPTY, s, nil) {
+					return true
+				}
 			}
 			continue
 		}
@@ -135,27 +437,61 @@ func validateConfigWithReflection(c interface{}) error {
 		// If this is a string field, check that it isn't empty (unless optional)
 		if s, ok := valueField.Interface().(string); ok {
 			if s == "" {
-				return fmt.Errorf("String Field: %s, contains an empty string", typeField.Name)
+				if v.report(fieldPath, fieldJSONPath, VALIDATION_RULE_NOT_EMPTY, s, nil) {
+					return true
+				}
 			}
 			continue
 		}
 
-		// If the Validater interface is implemented, call the Validate method
-		if typeField.Type.Implements(validaterType) {
-			if err := valueField.Interface().(Validater).Validate(); err != nil {
-				return fmt.Errorf("Validater Field: %s, failed to validate with error, %s", typeField.Name, err)
+		// If the Validater interface is implemented, call the Validate method. One promoted through a nil
+		// embedded pointer can't be called, the struct is walked below so the nil pointer is reported instead.
+		if i == covered {
+			continue
+		}
+		if field.validater && validateReachable(valueField) {
+			if err := asValidater(valueField).Validate(); err != nil {
+				if v.report(fieldPath, fieldJSONPath, VALIDATION_RULE_VALIDATE, valueField.Interface(), err) {
+					return true
+				}
 			}
 			continue
 		}
 
 		// If this field is a struct type, validate it with reflection
 		// We can/should only check the sub-fields of a Struct
-		if valueField.Elem().Kind() == reflect.Struct && valueField.Elem().NumField() > 0 {
-			if err := validateConfigWithReflection(valueField.Interface()); err != nil {
-				return fmt.Errorf("Sub Field of %s, failed to validate with error, %s", typeField.Name, err)
+		if valueField.Kind() == reflect.Ptr && valueField.Elem().Kind() == reflect.Struct && valueField.Elem().NumField() > 0 {
+			if v.validateStruct(valueField, fieldPath, fieldJSONPath) {
+				return true
+			}
+		} else if valueField.Kind() == reflect.Struct && valueField.NumField() > 0 {
+			if v.validateStruct(addressable(valueField).Addr(), fieldPath, fieldJSONPath) {
+				return true
 			}
 		}
 	}
 
-	return nil
+	return false
+}
+
+// Validates a slice, array or map element, elements that aren't structs or struct pointers are skipped.
+// Returns true when the walk should stop.
+func (v *configValidator) validateElement(elem reflect.Value, path string, jsonPath string) bool {
+	switch {
+	case elem.Kind() == reflect.Ptr && !elem.IsNil() && elem.Elem().Kind() == reflect.Struct:
+		return v.validateStruct(elem, path, jsonPath)
+	case elem.Kind() == reflect.Struct:
+		return v.validateStruct(addressable(elem).Addr(), path, jsonPath)
+	}
+	return false
+}
+
+// Returns the value if it's addressable, otherwise an addressable copy of it, i.e. for map values.
+func addressable(v reflect.Value) reflect.Value {
+	if v.CanAddr() {
+		return v
+	}
+	copied := reflect.New(v.Type()).Elem()
+	copied.Set(v)
+	return copied
 }
@@ -2,6 +2,7 @@ package remoteconfig
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
@@ -9,6 +10,7 @@ import (
 	"regexp"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
@@ -33,30 +35,30 @@ func TestRemoteConfigSuite(t *testing.T) {
 }
 
 type EmbeddedConfig struct {
-	EmbeddedStr *string `json:"embedded_string,omitempty"`
-	EmbeddedInt *int64  `json:"embedded_int,omitempty"`
+	EmbeddedStr *string `json:"embedded_string,omitempty" yaml:"embedded_string,omitempty"`
+	EmbeddedInt *int64  `json:"embedded_int,omitempty" yaml:"embedded_int,omitempty"`
 }
 
 type SampleConfig struct {
-	EmbeddedConfig
-	SQSQueueOptional           *SQSQueueConfig           `json:"sqs_queue_optional,omitempty" remoteconfig:"optional"`
-	SQSClientOptional          *SQSClientConfig          `json:"sqs_client_optional,omitempty" remoteconfig:"optional"`
-	DynamoDBTableOptional      *DynamoDBTableConfig      `json:"dynamodb_table_optional,omitempty" remoteconfig:"optional"`
-	DynamoDBClientOptional     *DynamoDBClientConfig     `json:"dynamodb_client_optional,omitempty" remoteconfig:"optional"`
-	StrOptional                *string                   `json:"str_optional,omitempty" remoteconfig:"optional"`
-	StorageConfigOptional      *StorageConfig            `json:"storage_config_optional,omitempty" remoteconfig:"optional"`
-	StorageConfigSliceOptional []*StorageConfig          `json:"storage_config_slice_optional,omitempty" remoteconfig:"optional"`
-	SQSQueue                   *SQSQueueConfig           `json:"sqs_queue,omitempty"`
-	SQSClient                  *SQSClientConfig          `json:"sqs_client,omitempty"`
-	DynamoDBTable              *DynamoDBTableConfig      `json:"dynamodb_table,omitempty"`
-	DynamoDBClient             *DynamoDBClientConfig     `json:"dynamodb_client,omitempty"`
-	Str                        string                    `json:"str,omitempty"`
-	StrPointer                 *string                   `json:"str_pointer,omitempty"`
-	StorageConfig              *StorageConfig            `json:"storage_config,omitempty"`
-	StorageConfigSlice         []*StorageConfig          `json:"storage_config_slice,omitempty"`
-	StorageConfigMap           map[string]*StorageConfig `json:"storage_config_map,omitempty"`
-	StrSlice                   []*string                 `json:"str_slice,omitempty" remoteconfig:"optional"`
-	MapStrStr                  map[string]*string        `json:"map_str_str,omitempty"`
+	EmbeddedConfig             `yaml:",inline"`
+	SQSQueueOptional           *SQSQueueConfig           `json:"sqs_queue_optional,omitempty" yaml:"sqs_queue_optional,omitempty" remoteconfig:"optional"`
+	SQSClientOptional          *SQSClientConfig          `json:"sqs_client_optional,omitempty" yaml:"sqs_client_optional,omitempty" remoteconfig:"optional"`
+	DynamoDBTableOptional      *DynamoDBTableConfig      `json:"dynamodb_table_optional,omitempty" yaml:"dynamodb_table_optional,omitempty" remoteconfig:"optional"`
+	DynamoDBClientOptional     *DynamoDBClientConfig     `json:"dynamodb_client_optional,omitempty" yaml:"dynamodb_client_optional,omitempty" remoteconfig:"optional"`
+	StrOptional                *string                   `json:"str_optional,omitempty" yaml:"str_optional,omitempty" remoteconfig:"optional"`
+	StorageConfigOptional      *StorageConfig            `json:"storage_config_optional,omitempty" yaml:"storage_config_optional,omitempty" remoteconfig:"optional"`
+	StorageConfigSliceOptional []*StorageConfig          `json:"storage_config_slice_optional,omitempty" yaml:"storage_config_slice_optional,omitempty" remoteconfig:"optional"`
+	SQSQueue                   *SQSQueueConfig           `json:"sqs_queue,omitempty" yaml:"sqs_queue,omitempty"`
+	SQSClient                  *SQSClientConfig          `json:"sqs_client,omitempty" yaml:"sqs_client,omitempty"`
+	DynamoDBTable              *DynamoDBTableConfig      `json:"dynamodb_table,omitempty" yaml:"dynamodb_table,omitempty"`
+	DynamoDBClient             *DynamoDBClientConfig     `json:"dynamodb_client,omitempty" yaml:"dynamodb_client,omitempty"`
+	Str                        string                    `json:"str,omitempty" yaml:"str,omitempty"`
+	StrPointer                 *string                   `json:"str_pointer,omitempty" yaml:"str_pointer,omitempty"`
+	StorageConfig              *StorageConfig            `json:"storage_config,omitempty" yaml:"storage_config,omitempty"`
+	StorageConfigSlice         []*StorageConfig          `json:"storage_config_slice,omitempty" yaml:"storage_config_slice,omitempty"`
+	StorageConfigMap           map[string]*StorageConfig `json:"storage_config_map,omitempty" yaml:"storage_config_map,omitempty"`
+	StrSlice                   []*string                 `json:"str_slice,omitempty" yaml:"str_slice,omitempty" remoteconfig:"optional"`
+	MapStrStr                  map[string]*string        `json:"map_str_str,omitempty" yaml:"map_str_str,omitempty"`
 }
 
 var validConfigJSON = `
@@ -103,6 +105,39 @@ var validConfigJSON = `
 		"map_str_str": { "key": "value" }
 	}`
 
+var validConfigYAML = `
+embedded_string: abc
+embedded_int: 123
+sqs_client:
+  region: us-east-1
+  endpoint: http://localhost:3000/sqs
+sqs_queue:
+  region: us-east-1
+  aws_account_id: "345833302425"
+  queue_name: testQueue
+dynamodb_client:
+  region: us-east-1
+  endpoint: http://localhost:8000/dynamodb
+dynamodb_table:
+  table_name: testTable
+str: testStr
+str_pointer: testStr
+storage_config:
+  provider: aws
+  location: us-west-2
+storage_config_slice:
+  - provider: aws
+    location: us-west-2
+  - provider: aws
+    location: us-east-1
+storage_config_map:
+  one:
+    provider: aws
+    location: us-west-2
+str_slice: [ hello ]
+map_str_str: { key: value }
+`
+
 func (s *RemoteConfigSuite) TestValidateConfigWithReflection() {
 	c := s.buildValidSampleConfig()
 	err := validateConfigWithReflection(c)
@@ -142,6 +177,7 @@ func (s *RemoteConfigSuite) TestValidateConfigWithReflectionWithOptional() {
 	}
 
 	c := &SampleConfig{
+		EmbeddedConfig:             s.buildValidEmbeddedConfig(),
 		SQSQueueOptional:           sqsQueue,
 		SQSClientOptional:          sqsClient,
 		DynamoDBTableOptional:      dynamodbTable,
@@ -167,11 +203,12 @@ func (s *RemoteConfigSuite) TestValidateConfigWithReflectionWithOptional() {
 
 func (s *RemoteConfigSuite) TestValidateConfigWithReflectionErrorSQSQueueConfigNotSet() {
 	c := &SampleConfig{
-		SQSQueue: nil,
+		EmbeddedConfig: s.buildValidEmbeddedConfig(),
+		SQSQueue:       nil,
 	}
 	err := validateConfigWithReflection(c)
 	assert.NotNil(s.T(), err)
-	assert.Equal(s.T(), errors.New("Field: SQSQueue, not set"), err)
+	assert.EqualError(s.T(), err, "Field: SQSQueue, not set")
 }
 
 func (s *RemoteConfigSuite) TestValidateConfigWithReflectionErrorSQSClientConfigNotSet() {
@@ -184,12 +221,13 @@ func (s *RemoteConfigSuite) TestValidateConfigWithReflectionErrorSQSClientConfig
 		QueueName:    &sqsQueueName,
 	}
 	c := &SampleConfig{
-		SQSQueue:  sqsQueue,
-		SQSClient: nil,
+		EmbeddedConfig: s.buildValidEmbeddedConfig(),
+		SQSQueue:       sqsQueue,
+		SQSClient:      nil,
 	}
 	err := validateConfigWithReflection(c)
 	assert.NotNil(s.T(), err)
-	assert.Equal(s.T(), errors.New("Field: SQSClient, not set"), err)
+	assert.EqualError(s.T(), err, "Field: SQSClient, not set")
 }
 
 func (s *RemoteConfigSuite) TestValidateConfigWithReflectionErrorSQSQueueConfigValidate() {
@@ -198,11 +236,12 @@ func (s *RemoteConfigSuite) TestValidateConfigWithReflectionErrorSQSQueueConfigV
 		Region: &sqsRegion,
 	}
 	c := &SampleConfig{
-		SQSQueue: sqsQueue,
+		EmbeddedConfig: s.buildValidEmbeddedConfig(),
+		SQSQueue:       sqsQueue,
 	}
 	err := validateConfigWithReflection(c)
 	assert.NotNil(s.T(), err)
-	assert.Equal(s.T(), errors.New("Sub Field of SQSQueue, failed to validate with error, Validater Field: Region, failed to validate with error, Region is invalid"), err)
+	assert.EqualError(s.T(), err, "Validater Field: SQSQueue.Region, failed to validate with error, Region is invalid")
 }
 
 func (s *RemoteConfigSuite) TestValidateConfigWithReflectionErrorDynamoDBTableConfigNotSet() {
@@ -219,14 +258,15 @@ func (s *RemoteConfigSuite) TestValidateConfigWithReflectionErrorDynamoDBTableCo
 	}
 
 	c := &SampleConfig{
-		SQSQueue:      sqsQueue,
-		SQSClient:     sqsClient,
-		DynamoDBTable: nil,
+		EmbeddedConfig: s.buildValidEmbeddedConfig(),
+		SQSQueue:       sqsQueue,
+		SQSClient:      sqsClient,
+		DynamoDBTable:  nil,
 	}
 
 	err := validateConfigWithReflection(c)
 	assert.NotNil(s.T(), err)
-	assert.Equal(s.T(), errors.New("Field: DynamoDBTable, not set"), err)
+	assert.EqualError(s.T(), err, "Field: DynamoDBTable, not set")
 }
 
 func (s *RemoteConfigSuite) TestValidateConfigWithReflectionErrorDynamoDBClientConfigNotSet() {
@@ -248,6 +288,7 @@ func (s *RemoteConfigSuite) TestValidateConfigWithReflectionErrorDynamoDBClientC
 	}
 
 	c := &SampleConfig{
+		EmbeddedConfig: s.buildValidEmbeddedConfig(),
 		SQSQueue:       sqsQueue,
 		SQSClient:      sqsClient,
 		DynamoDBTable:  dynamodbTable,
@@ -256,7 +297,7 @@ func (s *RemoteConfigSuite) TestValidateConfigWithReflectionErrorDynamoDBClientC
 
 	err := validateConfigWithReflection(c)
 	assert.NotNil(s.T(), err)
-	assert.Equal(s.T(), errors.New("Field: DynamoDBClient, not set"), err)
+	assert.EqualError(s.T(), err, "Field: DynamoDBClient, not set")
 }
 
 func (s *RemoteConfigSuite) TestValidateConfigWithReflectionErrorDynamoDBClientConfigValidate() {
@@ -283,6 +324,7 @@ func (s *RemoteConfigSuite) TestValidateConfigWithReflectionErrorDynamoDBClientC
 	}
 
 	c := &SampleConfig{
+		EmbeddedConfig: s.buildValidEmbeddedConfig(),
 		SQSQueue:       sqsQueue,
 		SQSClient:      sqsClient,
 		DynamoDBTable:  dynamodbTable,
@@ -291,7 +333,7 @@ func (s *RemoteConfigSuite) TestValidateConfigWithReflectionErrorDynamoDBClientC
 
 	err := validateConfigWithReflection(c)
 	assert.NotNil(s.T(), err)
-	assert.Equal(s.T(), errors.New("Sub Field of DynamoDBClient, failed to validate with error, Validater Field: Region, failed to validate with error, Region is invalid"), err)
+	assert.EqualError(s.T(), err, "Validater Field: DynamoDBClient.Region, failed to validate with error, Region is invalid")
 }
 
 func (s *RemoteConfigSuite) TestValidateConfigWithReflectionErrorDynamoDBTableConfigValidate() {
@@ -313,14 +355,15 @@ func (s *RemoteConfigSuite) TestValidateConfigWithReflectionErrorDynamoDBTableCo
 	}
 
 	c := &SampleConfig{
-		SQSQueue:      sqsQueue,
-		SQSClient:     sqsClient,
-		DynamoDBTable: dynamodbTable,
+		EmbeddedConfig: s.buildValidEmbeddedConfig(),
+		SQSQueue:       sqsQueue,
+		SQSClient:      sqsClient,
+		DynamoDBTable:  dynamodbTable,
 	}
 
 	err := validateConfigWithReflection(c)
 	assert.NotNil(s.T(), err)
-	assert.Equal(s.T(), errors.New("Sub Field of DynamoDBTable, failed to validate with error, String Field: TableName, contains an empty string"), err)
+	assert.EqualError(s.T(), err, "String Field: DynamoDBTable.TableName, contains an empty string")
 }
 
 func (s *RemoteConfigSuite) TestValidateConfigWithReflectionErrorStrNotSet() {
@@ -347,6 +390,7 @@ func (s *RemoteConfigSuite) TestValidateConfigWithReflectionErrorStrNotSet() {
 	}
 
 	c := &SampleConfig{
+		EmbeddedConfig: s.buildValidEmbeddedConfig(),
 		SQSQueue:       sqsQueue,
 		SQSClient:      sqsClient,
 		DynamoDBTable:  dynamodbTable,
@@ -357,7 +401,7 @@ func (s *RemoteConfigSuite) TestValidateConfigWithReflectionErrorStrNotSet() {
 
 	err := validateConfigWithReflection(c)
 	assert.NotNil(s.T(), err)
-	assert.Equal(s.T(), errors.New("Field: StrPointer, not set"), err)
+	assert.EqualError(s.T(), err, "Field: StrPointer, not set")
 }
 
 func (s *RemoteConfigSuite) TestValidateConfigWithReflectionErrorStrPointerEmpty() {
@@ -386,6 +430,7 @@ func (s *RemoteConfigSuite) TestValidateConfigWithReflectionErrorStrPointerEmpty
 	str := ""
 
 	c := &SampleConfig{
+		EmbeddedConfig: s.buildValidEmbeddedConfig(),
 		SQSQueue:       sqsQueue,
 		SQSClient:      sqsClient,
 		DynamoDBTable:  dynamodbTable,
@@ -396,7 +441,7 @@ func (s *RemoteConfigSuite) TestValidateConfigWithReflectionErrorStrPointerEmpty
 
 	err := validateConfigWithReflection(c)
 	assert.NotNil(s.T(), err)
-	assert.Equal(s.T(), errors.New("String Field: StrPointer, contains an empty string"), err)
+	assert.EqualError(s.T(), err, "String Field: StrPointer, contains an empty string")
 }
 
 func (s *RemoteConfigSuite) TestValidateConfigWithReflectionErrorStrEmpty() {
@@ -425,6 +470,7 @@ func (s *RemoteConfigSuite) TestValidateConfigWithReflectionErrorStrEmpty() {
 	str := ""
 
 	c := &SampleConfig{
+		EmbeddedConfig: s.buildValidEmbeddedConfig(),
 		SQSQueue:       sqsQueue,
 		SQSClient:      sqsClient,
 		DynamoDBTable:  dynamodbTable,
@@ -434,7 +480,7 @@ func (s *RemoteConfigSuite) TestValidateConfigWithReflectionErrorStrEmpty() {
 
 	err := validateConfigWithReflection(c)
 	assert.NotNil(s.T(), err)
-	assert.Equal(s.T(), errors.New("String Field: Str, contains an empty string"), err)
+	assert.EqualError(s.T(), err, "String Field: Str, contains an empty string")
 }
 
 func (s *RemoteConfigSuite) TestValidateConfigWithReflectionErrorStorageConfigNotSet() {
@@ -463,6 +509,7 @@ func (s *RemoteConfigSuite) TestValidateConfigWithReflectionErrorStorageConfigNo
 	str := "testString"
 
 	c := &SampleConfig{
+		EmbeddedConfig: s.buildValidEmbeddedConfig(),
 		SQSQueue:       sqsQueue,
 		SQSClient:      sqsClient,
 		DynamoDBTable:  dynamodbTable,
@@ -474,7 +521,7 @@ func (s *RemoteConfigSuite) TestValidateConfigWithReflectionErrorStorageConfigNo
 
 	err := validateConfigWithReflection(c)
 	assert.NotNil(s.T(), err)
-	assert.Equal(s.T(), errors.New("Field: StorageConfig, not set"), err)
+	assert.EqualError(s.T(), err, "Field: StorageConfig, not set")
 }
 
 func (s *RemoteConfigSuite) TestValidateConfigWithReflectionErrorStorageConfigSliceNotSet() {
@@ -510,6 +557,7 @@ func (s *RemoteConfigSuite) TestValidateConfigWithReflectionErrorStorageConfigSl
 	}
 
 	c := &SampleConfig{
+		EmbeddedConfig:     s.buildValidEmbeddedConfig(),
 		SQSQueue:           sqsQueue,
 		SQSClient:          sqsClient,
 		DynamoDBTable:      dynamodbTable,
@@ -522,7 +570,7 @@ func (s *RemoteConfigSuite) TestValidateConfigWithReflectionErrorStorageConfigSl
 
 	err := validateConfigWithReflection(c)
 	assert.NotNil(s.T(), err)
-	assert.Equal(s.T(), errors.New("Field: StorageConfigSlice, not set"), err)
+	assert.EqualError(s.T(), err, "Field: StorageConfigSlice, not set")
 }
 
 func (s *RemoteConfigSuite) TestValidateConfigWithReflectionErrorStorageConfigSliceNested() {
@@ -538,7 +586,7 @@ func (s *RemoteConfigSuite) TestValidateConfigWithReflectionErrorStorageConfigSl
 
 	err := validateConfigWithReflection(c)
 	s.NotNil(err)
-	s.Equal(errors.New("Validater Field: StorageConfig, failed to validate with error, Region cannot be empty"), err)
+	s.EqualError(err, "Validater Field: StorageConfigSlice[0], failed to validate with error, Region cannot be empty")
 }
 
 func (s *RemoteConfigSuite) TestValidateConfigWithReflectionErrorStorageConfigMapNested() {
@@ -554,7 +602,46 @@ func (s *RemoteConfigSuite) TestValidateConfigWithReflectionErrorStorageConfigMa
 
 	err := validateConfigWithReflection(c)
 	s.NotNil(err)
-	s.Equal(errors.New("Sub field of StorageConfigMap with key 'one' failed to validated with error, Validater Field: StorageConfig, failed to validate with error, Region cannot be empty"), err)
+	s.EqualError(err, `Validater Field: StorageConfigMap["one"], failed to validate with error, Region cannot be empty`)
+}
+
+func (s *RemoteConfigSuite) TestValidateConfigWithReflectionErrorPaths() {
+	storageProvider := VALID_REMOTE_CONFIG_STORAGE_CONFIG_PROVIDER
+	invalidStorageLocation := StorageLocation("")
+	invalidStorageConfig := &StorageConfig{
+		Provider: &storageProvider,
+		Location: &invalidStorageLocation,
+	}
+
+	c := s.buildValidSampleConfig()
+	c.StorageConfigMap = map[string]*StorageConfig{"one": invalidStorageConfig}
+
+	err := validateConfigWithReflection(c)
+	validationErr, ok := err.(*ValidationError)
+	s.True(ok)
+	s.Equal(`StorageConfigMap["one"]`, validationErr.Path)
+	s.Equal("storage_config_map.one", validationErr.JSONPath)
+	s.Equal(VALIDATION_RULE_VALIDATE, validationErr.Rule)
+	s.Equal(invalidStorageConfig, validationErr.Value)
+	s.True(errors.Is(err, ErrAWSRegionEmptyString))
+
+	c = s.buildValidSampleConfig()
+	c.SQSQueue = &SQSQueueConfig{Region: c.SQSQueue.Region, AWSAccountID: c.SQSQueue.AWSAccountID}
+	c.StorageConfigSlice = append(c.StorageConfigSlice, invalidStorageConfig)
+
+	err = validateAllConfigWithReflection(c)
+	errs := err.(ValidationErrors)
+	s.Len(errs, 2)
+
+	validationErr = errs[0].(*ValidationError)
+	s.Equal("SQSQueue.QueueName", validationErr.Path)
+	s.Equal("sqs_queue.queue_name", validationErr.JSONPath)
+	s.Equal(VALIDATION_RULE_REQUIRED, validationErr.Rule)
+	s.Nil(validationErr.Err)
+
+	validationErr = errs[1].(*ValidationError)
+	s.Equal("StorageConfigSlice[1]", validationErr.Path)
+	s.Equal("storage_config_slice[1]", validationErr.JSONPath)
 }
 
 func (s *RemoteConfigSuite) TestValidateConfigWithReflectionErrorStorageConfigSliceEmpty() {
@@ -590,6 +677,7 @@ func (s *RemoteConfigSuite) TestValidateConfigWithReflectionErrorStorageConfigSl
 	}
 
 	c := &SampleConfig{
+		EmbeddedConfig:     s.buildValidEmbeddedConfig(),
 		SQSQueue:           sqsQueue,
 		SQSClient:          sqsClient,
 		DynamoDBTable:      dynamodbTable,
@@ -602,7 +690,469 @@ func (s *RemoteConfigSuite) TestValidateConfigWithReflectionErrorStorageConfigSl
 
 	err := validateConfigWithReflection(c)
 	assert.NotNil(s.T(), err)
-	assert.Equal(s.T(), errors.New("Slice Field: StorageConfigSlice, is empty"), err)
+	assert.EqualError(s.T(), err, "Slice Field: StorageConfigSlice, is empty")
+}
+
+type EmbeddedValidaterConfig struct {
+	Name string `json:"name"`
+}
+
+func (c EmbeddedValidaterConfig) Validate() error {
+	if c.Name == "invalid" {
+		return errors.New("Name is invalid")
+	}
+	return nil
+}
+
+type OtherEmbeddedValidaterConfig struct {
+	Other string `json:"other"`
+}
+
+func (c OtherEmbeddedValidaterConfig) Validate() error {
+	if c.Other == "invalid" {
+		return errors.New("Other is invalid")
+	}
+	return nil
+}
+
+type EmbeddedPointerConfig struct {
+	*EmbeddedConfig
+}
+
+type EmbeddedValidaterPointerConfig struct {
+	*EmbeddedValidaterConfig
+}
+
+type AmbiguousValidaterConfig struct {
+	EmbeddedValidaterConfig
+	OtherEmbeddedValidaterConfig
+}
+
+type NamedEmbeddedConfig struct {
+	*EmbeddedConfig `json:"embedded"`
+}
+
+type EmbeddedValidaterRequiredConfig struct {
+	*EmbeddedValidaterConfig
+	B *string `json:"b"`
+}
+
+type EmbeddedValidaterOptionalConfig struct {
+	*EmbeddedValidaterConfig `remoteconfig:"optional"`
+	B                        *string `json:"b"`
+}
+
+type OwnValidaterEmbeddedConfig struct {
+	EmbeddedValidaterConfig
+	B *string `json:"b"`
+}
+
+func (c OwnValidaterEmbeddedConfig) Validate() error {
+	if c.B != nil && *c.B == "invalid" {
+		return errors.New("B is invalid")
+	}
+	return nil
+}
+
+type unexportedEmbeddedConfig struct {
+	Name *string `json:"name"`
+}
+
+type UnexportedEmbeddedConfig struct {
+	unexportedEmbeddedConfig
+	B *string `json:"b"`
+}
+
+func (s *RemoteConfigSuite) TestValidateConfigWithReflectionEmbeddedStruct() {
+	c := s.buildValidSampleConfig()
+	c.EmbeddedStr = nil
+
+	err := validateConfigWithReflection(c)
+	s.EqualError(err, "Field: EmbeddedStr, not set")
+	s.Equal("embedded_string", err.(*ValidationError).JSONPath)
+
+	empty := ""
+	c = s.buildValidSampleConfig()
+	c.EmbeddedStr = &empty
+	s.EqualError(validateConfigWithReflection(c), "String Field: EmbeddedStr, contains an empty string")
+}
+
+func (s *RemoteConfigSuite) TestValidateConfigWithReflectionEmbeddedPointer() {
+	s.EqualError(validateConfigWithReflection(&EmbeddedPointerConfig{}), "Field: EmbeddedConfig, not set")
+
+	embedded := s.buildValidEmbeddedConfig()
+	c := &EmbeddedPointerConfig{EmbeddedConfig: &embedded}
+	s.Nil(validateConfigWithReflection(c))
+
+	c.EmbeddedInt = nil
+	s.EqualError(validateConfigWithReflection(c), "Field: EmbeddedInt, not set")
+
+}
+
+func (s *RemoteConfigSuite) TestValidateConfigWithReflectionEmbeddedPointerValidater() {
+	c := &EmbeddedValidaterPointerConfig{EmbeddedValidaterConfig: &EmbeddedValidaterConfig{Name: "valid"}}
+	s.Nil(validateConfigWithReflection(c))
+
+	// The promoted Validate method is called once, for the outer struct
+	c.Name = "invalid"
+	err := validateAllConfigWithReflection(c)
+	s.EqualError(err, "Validater Field: EmbeddedValidaterPointerConfig, failed to validate with error, Name is invalid")
+	s.Len(err.(ValidationErrors), 1)
+}
+
+func (s *RemoteConfigSuite) TestValidateConfigWithReflectionEmbeddedNilPointerValidater() {
+	// The promoted Validate method isn't called through a nil embedded pointer
+	err := ReadJSONValidate(bytes.NewBufferString(`{"b": "x"}`), &EmbeddedValidaterRequiredConfig{})
+	s.EqualError(err, "Field: EmbeddedValidaterConfig, not set")
+	s.Equal(VALIDATION_RULE_REQUIRED, err.(*ValidationError).Rule)
+
+	s.Nil(ReadJSONValidate(bytes.NewBufferString(`{"b": "x"}`), &EmbeddedValidaterOptionalConfig{}))
+
+	err = ReadJSONValidate(bytes.NewBufferString(`{"b": "x", "name": "invalid"}`), &EmbeddedValidaterOptionalConfig{})
+	s.EqualError(err, "Validater Field: EmbeddedValidaterOptionalConfig, failed to validate with error, Name is invalid")
+}
+
+func (s *RemoteConfigSuite) TestValidateConfigWithReflectionEmbeddedValidaterShadowed() {
+	// The embedded struct's Validate is called as well as the outer struct's own
+	invalid := "invalid"
+	c := &OwnValidaterEmbeddedConfig{EmbeddedValidaterConfig: EmbeddedValidaterConfig{Name: "invalid"}, B: &invalid}
+	err := validateAllConfigWithReflection(c)
+	s.EqualError(err, "Config failed to validate with 2 errors, "+
+		"Validater Field: OwnValidaterEmbeddedConfig, failed to validate with error, B is invalid; "+
+		"Validater Field: EmbeddedValidaterConfig, failed to validate with error, Name is invalid")
+
+	valid := "valid"
+	c = &OwnValidaterEmbeddedConfig{EmbeddedValidaterConfig: EmbeddedValidaterConfig{Name: "invalid"}, B: &valid}
+	s.EqualError(validateConfigWithReflection(c), "Validater Field: EmbeddedValidaterConfig, failed to validate with error, Name is invalid")
+}
+
+func (s *RemoteConfigSuite) TestValidateConfigWithReflectionUnexportedEmbeddedStruct() {
+	err := ReadJSONValidate(bytes.NewBufferString(`{"b": "x"}`), &UnexportedEmbeddedConfig{})
+	s.EqualError(err, "Field: Name, not set")
+	s.Equal("name", err.(*ValidationError).JSONPath)
+
+	s.Nil(ReadJSONValidate(bytes.NewBufferString(`{"b": "x", "name": "a"}`), &UnexportedEmbeddedConfig{}))
+}
+
+func (s *RemoteConfigSuite) TestValidateConfigWithReflectionEmbeddedValidater() {
+	c := &AmbiguousValidaterConfig{
+		EmbeddedValidaterConfig:      EmbeddedValidaterConfig{Name: "invalid"},
+		OtherEmbeddedValidaterConfig: OtherEmbeddedValidaterConfig{Other: "invalid"},
+	}
+
+	err := validateAllConfigWithReflection(c)
+	s.EqualError(err, "Config failed to validate with 2 errors, "+
+		"Validater Field: EmbeddedValidaterConfig, failed to validate with error, Name is invalid; "+
+		"Validater Field: OtherEmbeddedValidaterConfig, failed to validate with error, Other is invalid")
+
+	c.Name = ""
+	s.EqualError(validateConfigWithReflection(c), "String Field: Name, contains an empty string")
+}
+
+func (s *RemoteConfigSuite) TestValidateConfigWithReflectionNamedEmbeddedStruct() {
+	err := validateConfigWithReflection(&NamedEmbeddedConfig{})
+	s.EqualError(err, "Field: EmbeddedConfig, not set")
+	s.Equal("embedded", err.(*ValidationError).JSONPath)
+
+	err = validateConfigWithReflection(&NamedEmbeddedConfig{EmbeddedConfig: &EmbeddedConfig{}})
+	s.EqualError(err, "Field: EmbeddedConfig.EmbeddedStr, not set")
+	s.Equal("embedded.embedded_string", err.(*ValidationError).JSONPath)
+}
+
+type ValueSubConfig struct {
+	Name   *string   `json:"name"`
+	Region AWSRegion `json:"region"`
+}
+
+type ValueConfig struct {
+	Sub             ValueSubConfig             `json:"sub"`
+	SubSlice        []ValueSubConfig           `json:"sub_slice"`
+	SubArray        [1]ValueSubConfig          `json:"sub_array"`
+	SubMap          map[string]ValueSubConfig  `json:"sub_map"`
+	SubSlicePointer *[]*ValueSubConfig         `json:"sub_slice_pointer"`
+	SubMapPointer   *map[string]ValueSubConfig `json:"sub_map_pointer"`
+	Updated         time.Time                  `json:"updated"`
+	unexported      *string
+}
+
+var validValueConfigJSON = `
+	{
+		"sub": {"name": "a", "region": "us-east-1"},
+		"sub_slice": [{"name": "b", "region": "us-east-1"}],
+		"sub_array": [{"name": "c", "region": "us-east-1"}],
+		"sub_map": {"one": {"name": "d", "region": "us-east-1"}},
+		"sub_slice_pointer": [{"name": "e", "region": "us-east-1"}],
+		"sub_map_pointer": {"one": {"name": "f", "region": "us-east-1"}},
+		"updated": "2016-01-02T15:04:05Z"
+	}`
+
+func (s *RemoteConfigSuite) buildValidValueConfig() *ValueConfig {
+	c := &ValueConfig{}
+	s.Nil(ReadJSONValidate(bytes.NewBufferString(validValueConfigJSON), c))
+	return c
+}
+
+func (s *RemoteConfigSuite) TestValidateConfigWithReflectionValueStructs() {
+	c := s.buildValidValueConfig()
+	s.Nil(validateConfigWithReflection(c))
+
+	c.Sub.Name = nil
+	err := validateConfigWithReflection(c)
+	s.EqualError(err, "Field: Sub.Name, not set")
+	s.Equal("sub.name", err.(*ValidationError).JSONPath)
+
+	c = s.buildValidValueConfig()
+	c.SubSlice[0].Region = "invalid"
+	s.EqualError(validateConfigWithReflection(c), "Validater Field: SubSlice[0].Region, failed to validate with error, Region is invalid")
+
+	c = s.buildValidValueConfig()
+	c.SubArray[0].Name = nil
+	s.EqualError(validateConfigWithReflection(c), "Field: SubArray[0].Name, not set")
+
+	c = s.buildValidValueConfig()
+	c.SubMap["one"] = ValueSubConfig{Region: AWS_REGION_US_EAST_1}
+	err = validateConfigWithReflection(c)
+	s.EqualError(err, `Field: SubMap["one"].Name, not set`)
+	s.Equal("sub_map.one.name", err.(*ValidationError).JSONPath)
+}
+
+func (s *RemoteConfigSuite) TestValidateConfigWithReflectionPointerSliceAndMap() {
+	c := s.buildValidValueConfig()
+	(*c.SubSlicePointer)[0].Name = nil
+	s.EqualError(validateConfigWithReflection(c), "Field: SubSlicePointer[0].Name, not set")
+
+	c = s.buildValidValueConfig()
+	*c.SubSlicePointer = []*ValueSubConfig{}
+	s.EqualError(validateConfigWithReflection(c), "Slice Field: SubSlicePointer, is empty")
+
+	c = s.buildValidValueConfig()
+	(*c.SubMapPointer)["two"] = ValueSubConfig{Region: "invalid"}
+	s.EqualError(validateAllConfigWithReflection(c), "Config failed to validate with 2 errors, "+
+		`Field: SubMapPointer["two"].Name, not set; `+
+		`Validater Field: SubMapPointer["two"].Region, failed to validate with error, Region is invalid`)
+
+	c = s.buildValidValueConfig()
+	c.SubMapPointer = nil
+	s.EqualError(validateConfigWithReflection(c), "Field: SubMapPointer, not set")
+}
+
+type ConstraintConfig struct {
+	Port     *int              `json:"port" remoteconfig:"min=1,max=65535"`
+	Timeout  time.Duration     `json:"timeout" remoteconfig:"optional,max=1m"`
+	Mode     string            `json:"mode" remoteconfig:"oneof=fast|safe"`
+	Hosts    []string          `json:"hosts" remoteconfig:"max=2"`
+	Labels   map[string]string `json:"labels" remoteconfig:"optional,min=1"`
+	Name     *string           `json:"name" remoteconfig:"regex=^[a-z-]+$"`
+	Optional *string           `json:"optional" remoteconfig:"optional,len=3"`
+}
+
+func (s *RemoteConfigSuite) TestValidateConfigWithReflectionConstraints() {
+	c := &ConstraintConfig{}
+	s.Nil(ReadJSONValidate(bytes.NewBufferString(`{"port": 8080, "mode": "fast", "hosts": ["a"], "name": "my-service"}`), c))
+
+	c = &ConstraintConfig{}
+	err := ReadValidate(bytes.NewBufferString(`
+		{
+			"port": 0,
+			"timeout": 120000000000,
+			"mode": "slow",
+			"hosts": ["a", "b", "c"],
+			"labels": {},
+			"name": "My Service",
+			"optional": "ab"
+		}`), FORMAT_JSON, c, WithAllValidationErrors())
+	s.EqualError(err, "Config failed to validate with 7 errors, "+
+		"Field: Port, must be at least 1; "+
+		"Field: Timeout, must be at most 1m; "+
+		"Field: Mode, must be one of fast, safe; "+
+		"Field: Hosts, length must be at most 2; "+
+		"Field: Labels, length must be at least 1; "+
+		"Field: Name, must match ^[a-z-]+$; "+
+		"Field: Optional, length must be 3")
+
+	validationErr := err.(ValidationErrors)[0].(*ValidationError)
+	s.Equal("Port", validationErr.Path)
+	s.Equal("port", validationErr.JSONPath)
+	s.Equal(VALIDATION_RULE_MIN, validationErr.Rule)
+	s.Equal("1", validationErr.Param)
+	s.Equal(c.Port, validationErr.Value)
+}
+
+type CrossFieldStorageConfig struct {
+	Provider *StorageProvider `json:"provider"`
+	Location *StorageLocation `json:"location" remoteconfig:"optional,required_if=Provider:aws"`
+	Path     *string          `json:"path" remoteconfig:"optional,excluded_with=Location"`
+}
+
+type CrossFieldSampleConfig struct {
+	Storage map[string]*CrossFieldStorageConfig `json:"storage"`
+}
+
+func (s *RemoteConfigSuite) TestValidateConfigWithReflectionCrossField() {
+	c := &CrossFieldSampleConfig{}
+	err := ReadValidate(bytes.NewBufferString(`
+		{
+			"storage": {
+				"logs": {"provider": "aws", "location": "us-east-1"},
+				"media": {"provider": "aws"},
+				"thumbs": {"provider": "aws", "location": "us-west-2", "path": "/thumbs"}
+			}
+		}`), FORMAT_JSON, c, WithAllValidationErrors())
+	s.EqualError(err, "Config failed to validate with 2 errors, "+
+		`Field: Storage["media"].Location, required when Storage["media"].Provider is aws; `+
+		`Field: Storage["thumbs"].Path, must not be set when Storage["thumbs"].Location is set`)
+
+	validationErr := err.(ValidationErrors)[0].(*ValidationError)
+	s.Equal("storage.media.location", validationErr.JSONPath)
+	s.Equal(VALIDATION_RULE_REQUIRED_IF, validationErr.Rule)
+	s.Equal("Provider:aws", validationErr.Param)
+}
+
+type PointerValidaterSampleConfig struct {
+	PointerValidaterConfig
+	Value   PointerValidaterConfig             `json:"value"`
+	Pointer *PointerValidaterConfig            `json:"pointer"`
+	Slice   []PointerValidaterConfig           `json:"slice"`
+	Map     map[string]PointerValidaterConfig  `json:"map"`
+	Ptrs    map[string]*PointerValidaterConfig `json:"ptrs"`
+}
+
+func (s *RemoteConfigSuite) TestValidateConfigWithReflectionPointerReceiver() {
+	c := &PointerValidaterSampleConfig{}
+	s.Nil(ReadJSONValidate(bytes.NewBufferString(`
+		{
+			"name": "a",
+			"value": {"name": "b"},
+			"pointer": {"name": "c"},
+			"slice": [{"name": "d"}],
+			"map": {"one": {"name": "e"}},
+			"ptrs": {"one": {"name": "f"}}
+		}`), c))
+
+	c = &PointerValidaterSampleConfig{}
+	err := ReadValidate(bytes.NewBufferString(`
+		{
+			"name": "invalid",
+			"value": {"name": "invalid"},
+			"pointer": {"name": "invalid"},
+			"slice": [{"name": "invalid"}],
+			"map": {"one": {"name": "invalid"}},
+			"ptrs": {"one": {"name": "invalid"}}
+		}`), FORMAT_JSON, c, WithAllValidationErrors())
+	s.EqualError(err, "Config failed to validate with 6 errors, "+
+		"Validater Field: PointerValidaterSampleConfig, failed to validate with error, Name is invalid; "+
+		"Validater Field: Value, failed to validate with error, Name is invalid; "+
+		"Validater Field: Pointer, failed to validate with error, Name is invalid; "+
+		"Validater Field: Slice[0], failed to validate with error, Name is invalid; "+
+		`Validater Field: Map["one"], failed to validate with error, Name is invalid; `+
+		`Validater Field: Ptrs["one"], failed to validate with error, Name is invalid`)
+}
+
+func (s *RemoteConfigSuite) TestReadValidateWithStrictDecoding() {
+	doc := strings.Replace(validConfigJSON, `"table_name"`, `"tabel_name"`, 1)
+
+	err := ReadValidate(bytes.NewBufferString(doc), FORMAT_JSON, &SampleConfig{}, WithStrictDecoding())
+	s.EqualError(err, "Failed to decode JSON, with error, json: unknown field \"tabel_name\", at line 19 column 4, in dynamodb_table.tabel_name, near `\"tabel_name\" : \"testTable\"`")
+
+	// Without strict decoding the misspelt key just looks missing
+	err = ReadValidate(bytes.NewBufferString(doc), FORMAT_JSON, &SampleConfig{})
+	s.EqualError(err, "Field: DynamoDBTable.TableName, not set")
+}
+
+func (s *RemoteConfigSuite) TestLoadConfigFromURLWithUnknownKeyWarnings() {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, strings.Replace(validConfigYAML, "str: testStr", "str: testStr\nstr_optinal: testStr", 1))
+	}))
+	defer ts.Close()
+
+	warnings := []string{}
+	c := &SampleConfig{}
+	err := LoadConfigFromURLWithOptions(context.Background(), ts.URL+"/config.yaml", c, WithUnknownKeyWarnings(func(paths []string) {
+		warnings = append(warnings, paths...)
+	}))
+	s.Nil(err)
+	s.Equal([]string{"str_optinal"}, warnings)
+	s.Equal("testStr", c.Str)
+
+	err = LoadConfigFromURLWithOptions(context.Background(), ts.URL+"/config.yaml", &SampleConfig{}, WithStrictDecoding())
+	s.NotNil(err)
+	s.Contains(err.Error(), "field str_optinal not found")
+}
+
+func (s *RemoteConfigSuite) TestReadValidateWithUnknownKeyWarningsNone() {
+	called := false
+	err := ReadValidate(bytes.NewBufferString(validConfigJSON), FORMAT_JSON, &SampleConfig{}, WithUnknownKeyWarnings(func(paths []string) {
+		called = true
+	}))
+	s.Nil(err)
+	s.False(called)
+}
+
+func (s *RemoteConfigSuite) TestValidateAllConfigWithReflection() {
+	c := s.buildValidSampleConfig()
+	s.Nil(validateAllConfigWithReflection(c))
+}
+
+func (s *RemoteConfigSuite) TestValidateAllConfigWithReflectionErrors() {
+	storageProvider := VALID_REMOTE_CONFIG_STORAGE_CONFIG_PROVIDER
+	invalidStorageLocation := StorageLocation("")
+	invalidStorageConfig := &StorageConfig{
+		Provider: &storageProvider,
+		Location: &invalidStorageLocation,
+	}
+	invalidRegion := AWSRegion("invalidregion")
+	emptyTableName := ""
+
+	c := s.buildValidSampleConfig()
+	c.SQSQueue = nil
+	c.SQSClient.Region = &invalidRegion
+	c.DynamoDBTable.TableName = &emptyTableName
+	c.Str = ""
+	c.StorageConfigSlice = []*StorageConfig{invalidStorageConfig}
+	c.StorageConfigMap = map[string]*StorageConfig{"two": invalidStorageConfig, "one": invalidStorageConfig}
+
+	err := validateAllConfigWithReflection(c)
+	s.NotNil(err)
+
+	errs := err.(ValidationErrors)
+	msgs := make([]string, len(errs))
+	for i, e := range errs {
+		msgs[i] = e.Error()
+	}
+	s.Equal([]string{
+		"Field: SQSQueue, not set",
+		"Validater Field: SQSClient.Region, failed to validate with error, Region is invalid",
+		"String Field: DynamoDBTable.TableName, contains an empty string",
+		"String Field: Str, contains an empty string",
+		"Validater Field: StorageConfigSlice[0], failed to validate with error, Region cannot be empty",
+		`Validater Field: StorageConfigMap["one"], failed to validate with error, Region cannot be empty`,
+		`Validater Field: StorageConfigMap["two"], failed to validate with error, Region cannot be empty`,
+	}, msgs)
+
+	// The first error matches the default mode
+	s.Equal(err.(ValidationErrors)[0], validateConfigWithReflection(c))
+}
+
+func (s *RemoteConfigSuite) TestReadValidateWithAllValidationErrors() {
+	err := ReadValidate(bytes.NewBufferString(`{"str": "testStr"}`), FORMAT_JSON, &SampleConfig{}, WithAllValidationErrors())
+	s.NotNil(err)
+
+	errs, ok := err.(ValidationErrors)
+	s.True(ok)
+	s.Len(errs, 11)
+	s.EqualError(errs[0], "Field: EmbeddedStr, not set")
+	s.EqualError(errs[1], "Field: EmbeddedInt, not set")
+	s.EqualError(errs[10], "Field: MapStrStr, not set")
+}
+
+func (s *RemoteConfigSuite) TestLoadConfigFromURLWithAllValidationErrors() {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, "{}")
+	}))
+	defer ts.Close()
+
+	err := LoadConfigFromURLWithOptions(context.Background(), ts.URL, &SQSQueueConfig{}, WithAllValidationErrors())
+	s.EqualError(err, "Config failed to validate with 3 errors, Field: Region, not set; Field: AWSAccountID, not set; Field: QueueName, not set")
 }
 
 func (s *RemoteConfigSuite) TestLoadConfigFromURL_Gold() {
@@ -636,6 +1186,102 @@ func (s *RemoteConfigSuite) TestLoadConfigFromURLError() {
 	assert.EqualError(s.T(), err, "Get \"invalid\": unsupported protocol scheme \"\"")
 }
 
+func (s *RemoteConfigSuite) TestLoadConfigFromURLWithContextTimeout() {
+	unblock := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-unblock
+	}))
+	defer ts.Close()
+	defer close(unblock)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	err := LoadConfigFromURLWithContext(ctx, ts.URL, &SampleConfig{})
+	s.NotNil(err)
+	s.True(errors.Is(err, ErrTimeout))
+	s.True(errors.Is(err, context.DeadlineExceeded))
+}
+
+func (s *RemoteConfigSuite) TestLoadConfigFromURLWithContextTimeoutReadingBody() {
+	unblock := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"sqs_queue": {`)
+		w.(http.Flusher).Flush()
+		<-unblock
+	}))
+	defer ts.Close()
+	defer close(unblock)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	err := LoadConfigFromURLWithContext(ctx, ts.URL, &SampleConfig{})
+	s.NotNil(err)
+	s.True(errors.Is(err, ErrTimeout))
+}
+
+func (s *RemoteConfigSuite) TestLoadConfigFromURLWithContextCanceled() {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, validConfigJSON)
+	}))
+	defer ts.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := LoadConfigFromURLWithContext(ctx, ts.URL, &SampleConfig{})
+	s.NotNil(err)
+	s.True(errors.Is(err, context.Canceled))
+	s.False(errors.Is(err, ErrTimeout))
+}
+
+func (s *RemoteConfigSuite) TestLoadConfigFromURLWithOptions() {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" || r.Header.Get("User-Agent") != "service/1.0" || r.Header.Get("X-Tenant") != "one" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprintln(w, validConfigJSON)
+	}))
+	defer ts.Close()
+
+	transport := &countingTransport{}
+	err := LoadConfigFromURLWithOptions(context.Background(), ts.URL, &SampleConfig{},
+		WithHTTPClient(&http.Client{Transport: transport}),
+		WithBearerToken("token"),
+		WithUserAgent("service/1.0"),
+		WithHeader("X-Tenant", "one"))
+	s.Nil(err)
+	s.Equal(1, transport.count)
+}
+
+func (s *RemoteConfigSuite) TestLoadConfigFromURLWithOptionsBasicAuth() {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if username, password, ok := r.BasicAuth(); !ok || username != "user" || password != "pass" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprintln(w, validConfigJSON)
+	}))
+	defer ts.Close()
+
+	err := LoadConfigFromURLWithOptions(context.Background(), ts.URL, &SampleConfig{}, WithBasicAuth("user", "wrong"))
+	s.NotNil(err)
+	s.Contains(err.Error(), "'401: Unauthorized'")
+
+	err = LoadConfigFromURLWithOptions(context.Background(), ts.URL, &SampleConfig{}, WithBasicAuth("user", "pass"))
+	s.Nil(err)
+}
+
+func (s *RemoteConfigSuite) TestReadJSONValidateWithContextCanceled() {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := ReadJSONValidateWithContext(ctx, bytes.NewBufferString(validConfigJSON), &SampleConfig{})
+	s.Equal(context.Canceled, err)
+}
+
 func (s *RemoteConfigSuite) TestReadJSONValidate() {
 	cfgBuffer := bytes.NewBufferString(validConfigJSON)
 
@@ -672,7 +1318,7 @@ func (s *RemoteConfigSuite) TestReadJSONValidateInvalidJSON() {
 	c := &SampleConfig{}
 	err := ReadJSONValidate(cfgBuffer, c)
 	assert.NotNil(s.T(), err)
-	assert.Equal(s.T(), errors.New("Failed to decode JSON, with error, invalid character 'N' looking for beginning of value"), err)
+	assert.EqualError(s.T(), err, "Failed to decode JSON, with error, invalid character 'N' looking for beginning of value, at line 1 column 1, near `Not JSON`")
 }
 
 func (s *RemoteConfigSuite) TestReadJSONValidateErrorValidation() {
@@ -681,7 +1327,7 @@ func (s *RemoteConfigSuite) TestReadJSONValidateErrorValidation() {
 	c := &SampleConfig{}
 	err := ReadJSONValidate(cfgBuffer, c)
 	assert.NotNil(s.T(), err)
-	assert.Equal(s.T(), errors.New("Field: SQSQueue, not set"), err)
+	assert.EqualError(s.T(), err, "Field: EmbeddedStr, not set")
 }
 
 func (s *RemoteConfigSuite) TestReadJSONValidateErrorInvalidJSON() {
@@ -697,7 +1343,108 @@ func (s *RemoteConfigSuite) TestReadJSONValidateErrorInvalidJSON() {
 	err := ReadJSONValidate(resp.Body, c)
 
 	assert.NotNil(s.T(), err)
-	assert.Equal(s.T(), errors.New("Failed to decode JSON, with error, invalid character 'T' looking for beginning of value"), err)
+	assert.EqualError(s.T(), err, "Failed to decode JSON, with error, invalid character 'T' looking for beginning of value, at line 1 column 1, near `This is NOT JSON`")
+}
+
+func (s *RemoteConfigSuite) TestReadYAMLValidate() {
+	c := &SampleConfig{}
+	err := ReadYAMLValidate(bytes.NewBufferString(validConfigYAML), c)
+	s.Nil(err)
+	s.EqualValues("abc", *c.EmbeddedStr)
+	s.EqualValues(123, *c.EmbeddedInt)
+	s.Equal(AWS_REGION_US_EAST_1, *c.SQSQueue.Region)
+	s.Equal("345833302425", *c.SQSQueue.AWSAccountID)
+	s.Equal(STORAGE_PROVIDER_AWS, *c.StorageConfigMap["one"].Provider)
+	s.Len(c.StorageConfigSlice, 2)
+	s.Nil(c.SQSQueueOptional)
+}
+
+func (s *RemoteConfigSuite) TestReadYAMLValidateMatchesJSON() {
+	yamlConfig := &SampleConfig{}
+	s.Nil(ReadYAMLValidate(bytes.NewBufferString(validConfigYAML), yamlConfig))
+
+	jsonConfig := &SampleConfig{}
+	s.Nil(ReadJSONValidate(bytes.NewBufferString(validConfigJSON), jsonConfig))
+
+	s.Equal(jsonConfig, yamlConfig)
+}
+
+func (s *RemoteConfigSuite) TestReadYAMLValidateErrorUnmarshalText() {
+	invalidConfigYAML := strings.Replace(validConfigYAML, "provider: aws", "provider: gcs", 1)
+
+	err := ReadYAMLValidate(bytes.NewBufferString(invalidConfigYAML), &SampleConfig{})
+	s.NotNil(err)
+	s.Contains(err.Error(), "Failed to decode YAML")
+	s.Contains(err.Error(), "Invalid storage provider")
+}
+
+func (s *RemoteConfigSuite) TestReadYAMLValidateErrorInvalidYAML() {
+	err := ReadYAMLValidate(bytes.NewBufferString("sqs_queue: [ unclosed"), &SampleConfig{})
+	s.NotNil(err)
+	s.Contains(err.Error(), "Failed to decode YAML, with error, yaml: line 1")
+}
+
+func (s *RemoteConfigSuite) TestReadYAMLValidateErrorValidation() {
+	err := ReadYAMLValidate(bytes.NewBufferString("str: testStr"), &SampleConfig{})
+	s.NotNil(err)
+	s.EqualError(err, "Field: EmbeddedStr, not set")
+}
+
+func (s *RemoteConfigSuite) TestReadYAMLValidateWithContextCanceled() {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := ReadYAMLValidateWithContext(ctx, bytes.NewBufferString(validConfigYAML), &SampleConfig{})
+	s.Equal(context.Canceled, err)
+}
+
+func (s *RemoteConfigSuite) TestReadValidate() {
+	c := &SampleConfig{}
+	err := ReadValidate(bytes.NewBufferString(validConfigTOML), FORMAT_TOML, c)
+	s.Nil(err)
+	s.Equal("testStr", c.Str)
+}
+
+func (s *RemoteConfigSuite) TestReadValidateErrorFormatInvalid() {
+	err := ReadValidate(bytes.NewBufferString(validConfigJSON), Format("xml"), &SampleConfig{})
+	s.Equal(ErrFormatInvalid, err)
+}
+
+func (s *RemoteConfigSuite) TestReadValidateErrorValidation() {
+	err := ReadValidate(bytes.NewBufferString("str = \"testStr\""), FORMAT_TOML, &SampleConfig{})
+	s.EqualError(err, "Field: EmbeddedStr, not set")
+}
+
+func (s *RemoteConfigSuite) TestLoadConfigFromURLFormatFromContentType() {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/x-yaml")
+		fmt.Fprintln(w, validConfigYAML)
+	}))
+	defer ts.Close()
+
+	err := LoadConfigFromURL(ts.URL+"/config.json", &SampleConfig{})
+	s.Nil(err)
+}
+
+func (s *RemoteConfigSuite) TestLoadConfigFromURLFormatFromExtension() {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, validConfigYAML)
+	}))
+	defer ts.Close()
+
+	err := LoadConfigFromURL(ts.URL+"/config.yml?version=2", &SampleConfig{})
+	s.Nil(err)
+}
+
+func (s *RemoteConfigSuite) TestLoadConfigFromURLWithOptionsFormat() {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintln(w, validConfigTOML)
+	}))
+	defer ts.Close()
+
+	err := LoadConfigFromURLWithOptions(context.Background(), ts.URL+"/config.json", &SampleConfig{}, WithFormat(FORMAT_TOML))
+	s.Nil(err)
 }
 
 func (s *RemoteConfigSuite) buildValidSampleConfig() *SampleConfig {
@@ -733,6 +1480,7 @@ func (s *RemoteConfigSuite) buildValidSampleConfig() *SampleConfig {
 	}
 
 	return &SampleConfig{
+		EmbeddedConfig:     s.buildValidEmbeddedConfig(),
 		SQSQueue:           sqsQueue,
 		SQSClient:          sqsClient,
 		DynamoDBTable:      dynamodbTable,
@@ -745,3 +1493,12 @@ func (s *RemoteConfigSuite) buildValidSampleConfig() *SampleConfig {
 		MapStrStr:          map[string]*string{"hello": &str},
 	}
 }
+
+func (s *RemoteConfigSuite) buildValidEmbeddedConfig() EmbeddedConfig {
+	embeddedStr := "abc"
+	embeddedInt := int64(123)
+	return EmbeddedConfig{
+		EmbeddedStr: &embeddedStr,
+		EmbeddedInt: &embeddedInt,
+	}
+}
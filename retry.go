@@ -0,0 +1,181 @@
+package remoteconfig
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	DEFAULT_RETRY_MAX_ATTEMPTS int           = 3
+	DEFAULT_RETRY_BASE_DELAY   time.Duration = 100 * time.Millisecond
+	DEFAULT_RETRY_MAX_DELAY    time.Duration = 5 * time.Second
+	DEFAULT_RETRY_JITTER       float64       = 0.2
+)
+
+var DefaultRetryableStatusCodes = []int{
+	http.StatusRequestTimeout,
+	http.StatusTooManyRequests,
+	http.StatusInternalServerError,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// Controls how config downloads are retried.
+// The delay starts at BaseDelay and doubles after each attempt, up to MaxDelay.
+// A Retry-After header on a 429 or 503 response replaces the computed delay.
+type RetryPolicy struct {
+	MaxAttempts          int                  // Total attempts including the first one
+	BaseDelay            time.Duration        // Delay before the first retry
+	MaxDelay             time.Duration        // Upper bound of a single delay
+	Jitter               float64              // Fraction of each delay that is randomized, from 0 to 1
+	RetryableStatusCodes []int                // Defaults to DefaultRetryableStatusCodes
+	IsRetryable          func(err error) bool // Replaces the status code and network error checks when set
+}
+
+// Returns a policy of 3 attempts with delays from 100ms to 5s and 20% jitter.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: DEFAULT_RETRY_MAX_ATTEMPTS,
+		BaseDelay:   DEFAULT_RETRY_BASE_DELAY,
+		MaxDelay:    DEFAULT_RETRY_MAX_DELAY,
+		Jitter:      DEFAULT_RETRY_JITTER,
+	}
+}
+
+// Returned when every attempt of a retried download failed.
+// errors.Is and errors.As match against the error of any attempt.
+type RetryError struct {
+	Attempts []error
+}
+
+func (e *RetryError) Error() string {
+	msgs := make([]string, len(e.Attempts))
+	for i, err := range e.Attempts {
+		msgs[i] = fmt.Sprintf("attempt %d: %s", i+1, err)
+	}
+	return fmt.Sprintf("Config download failed after %d attempts, with errors, %s", len(e.Attempts), strings.Join(msgs, "; "))
+}
+
+// Returns the error of the last attempt.
+func (e *RetryError) Unwrap() error {
+	return e.Attempts[len(e.Attempts)-1]
+}
+
+func (e *RetryError) Is(target error) bool {
+	for _, err := range e.Attempts {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+func (e *RetryError) As(target interface{}) bool {
+	for _, err := range e.Attempts {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// Retries the download when the load options have a retry policy.
+func WithRetry(policy RetryPolicy) LoadOption {
+	return func(o *loadOptions) {
+		o.retry = &policy
+	}
+}
+
+// Runs fn until it succeeds, returns a non retryable error, the attempts run out or the context is done.
+// A single failed attempt returns its error as is, otherwise a RetryError with every attempt's error.
+func (p RetryPolicy) do(ctx context.Context, fn func() error) error {
+	errs := []error{}
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil {
+			return nil
+		}
+		errs = append(errs, wrapContextError(err))
+
+		if attempt >= p.MaxAttempts || ctx.Err() != nil || !p.isRetryable(err) {
+			break
+		}
+
+		timer := time.NewTimer(p.delay(attempt, err))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return &RetryError{Attempts: append(errs, wrapContextError(ctx.Err()))}
+		case <-timer.C:
+		}
+	}
+
+	if len(errs) == 1 {
+		return errs[0]
+	}
+	return &RetryError{Attempts: errs}
+}
+
+// Returns the delay before the attempt following the given one.
+func (p RetryPolicy) delay(attempt int, err error) time.Duration {
+	var statusErr *HTTPStatusError
+	if errors.As(err, &statusErr) && statusErr.RetryAfter > 0 &&
+		(statusErr.StatusCode == http.StatusTooManyRequests || statusErr.StatusCode == http.StatusServiceUnavailable) {
+		return statusErr.RetryAfter
+	}
+
+	delay := p.BaseDelay
+	for i := 1; i < attempt && (p.MaxDelay <= 0 || delay < p.MaxDelay); i++ {
+		delay *= 2
+	}
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+
+	if p.Jitter > 0 {
+		delay -= time.Duration(rand.Float64() * p.Jitter * float64(delay))
+	}
+	return delay
+}
+
+func (p RetryPolicy) isRetryable(err error) bool {
+	if p.IsRetryable != nil {
+		return p.IsRetryable(err)
+	}
+
+	if errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	var statusErr *HTTPStatusError
+	if errors.As(err, &statusErr) {
+		codes := p.RetryableStatusCodes
+		if codes == nil {
+			codes = DefaultRetryableStatusCodes
+		}
+		for _, code := range codes {
+			if code == statusErr.StatusCode {
+				return true
+			}
+		}
+		return false
+	}
+
+	// url.Error implements net.Error itself, so check what it wraps instead
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		err = urlErr.Err
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+}
@@ -0,0 +1,202 @@
+package remoteconfig
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type RetrySuite struct {
+	suite.Suite
+}
+
+func TestRetrySuite(t *testing.T) {
+	suite.Run(t, new(RetrySuite))
+}
+
+func (s *RetrySuite) buildPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 3,
+		BaseDelay:   time.Millisecond,
+		MaxDelay:    10 * time.Millisecond,
+	}
+}
+
+func (s *RetrySuite) TestLoadConfigFromURLWithRetry() {
+	var requests int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		fmt.Fprintln(w, validConfigJSON)
+	}))
+	defer ts.Close()
+
+	err := LoadConfigFromURLWithOptions(context.Background(), ts.URL, &SampleConfig{}, WithRetry(s.buildPolicy()))
+	s.Nil(err)
+	s.EqualValues(3, atomic.LoadInt32(&requests))
+}
+
+func (s *RetrySuite) TestLoadConfigFromURLWithRetryErrorAttemptsExhausted() {
+	var requests int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer ts.Close()
+
+	err := LoadConfigFromURLWithOptions(context.Background(), ts.URL, &SampleConfig{}, WithRetry(s.buildPolicy()))
+	s.NotNil(err)
+	s.EqualValues(3, atomic.LoadInt32(&requests))
+
+	var retryErr *RetryError
+	s.True(errors.As(err, &retryErr))
+	s.Len(retryErr.Attempts, 3)
+	statusErr := fmt.Sprintf("Request to '%s' returned non-200 OK status '503: Service Unavailable'", ts.URL)
+	s.EqualError(err, fmt.Sprintf("Config download failed after 3 attempts, with errors, attempt 1: %s; attempt 2: %s; attempt 3: %s", statusErr, statusErr, statusErr))
+
+	var httpErr *HTTPStatusError
+	s.True(errors.As(err, &httpErr))
+	s.Equal(http.StatusServiceUnavailable, httpErr.StatusCode)
+}
+
+func (s *RetrySuite) TestLoadConfigFromURLWithRetryErrorNotRetryable() {
+	var requests int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer ts.Close()
+
+	err := LoadConfigFromURLWithOptions(context.Background(), ts.URL, &SampleConfig{}, WithRetry(s.buildPolicy()))
+	s.EqualValues(1, atomic.LoadInt32(&requests))
+	s.IsType(&HTTPStatusError{}, err)
+}
+
+func (s *RetrySuite) TestLoadConfigFromURLWithRetryNotAppliedToValidation() {
+	var requests int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		fmt.Fprintln(w, "{}")
+	}))
+	defer ts.Close()
+
+	err := LoadConfigFromURLWithOptions(context.Background(), ts.URL, &SQSQueueConfig{}, WithRetry(s.buildPolicy()))
+	s.EqualValues(1, atomic.LoadInt32(&requests))
+	s.EqualError(err, "Field: Region, not set")
+}
+
+func (s *RetrySuite) TestLoadWithRetryRetryAfter() {
+	var requests int32
+	var firstRequest time.Time
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) == 1 {
+			firstRequest = time.Now()
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		s.True(time.Since(firstRequest) >= time.Second)
+		fmt.Fprintln(w, validConfigJSON)
+	}))
+	defer ts.Close()
+
+	err := Load(context.Background(), ts.URL, &SampleConfig{}, WithRetry(s.buildPolicy()))
+	s.Nil(err)
+	s.EqualValues(2, atomic.LoadInt32(&requests))
+}
+
+func (s *RetrySuite) TestLoadWithRetryContextDone() {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer ts.Close()
+
+	policy := s.buildPolicy()
+	policy.BaseDelay = time.Minute
+	policy.MaxDelay = time.Minute
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	err := Load(ctx, ts.URL, &SampleConfig{}, WithRetry(policy))
+	s.True(errors.Is(err, ErrTimeout))
+
+	var retryErr *RetryError
+	s.True(errors.As(err, &retryErr))
+	s.Len(retryErr.Attempts, 2)
+	s.IsType(&HTTPStatusError{}, retryErr.Attempts[0])
+}
+
+func (s *RetrySuite) TestDoIsRetryable() {
+	attempts := 0
+	policy := s.buildPolicy()
+	policy.IsRetryable = func(err error) bool {
+		return err.Error() == "retry"
+	}
+
+	err := policy.do(context.Background(), func() error {
+		attempts++
+		if attempts == 1 {
+			return errors.New("retry")
+		}
+		return errors.New("stop")
+	})
+	s.Equal(2, attempts)
+	s.EqualError(err, "Config download failed after 2 attempts, with errors, attempt 1: retry; attempt 2: stop")
+}
+
+func (s *RetrySuite) TestDoSingleAttempt() {
+	attempts := 0
+	err := RetryPolicy{}.do(context.Background(), func() error {
+		attempts++
+		return io.ErrUnexpectedEOF
+	})
+	s.Equal(1, attempts)
+	s.Equal(io.ErrUnexpectedEOF, err)
+}
+
+func (s *RetrySuite) TestDelay() {
+	policy := RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: 300 * time.Millisecond}
+	s.Equal(100*time.Millisecond, policy.delay(1, io.EOF))
+	s.Equal(200*time.Millisecond, policy.delay(2, io.EOF))
+	s.Equal(300*time.Millisecond, policy.delay(3, io.EOF))
+	s.Equal(300*time.Millisecond, policy.delay(30, io.EOF))
+}
+
+func (s *RetrySuite) TestDelayJitter() {
+	policy := RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second, Jitter: 0.5}
+	for i := 0; i < 100; i++ {
+		delay := policy.delay(1, io.EOF)
+		s.True(delay > 50*time.Millisecond && delay <= 100*time.Millisecond)
+	}
+}
+
+func (s *RetrySuite) TestDelayRetryAfter() {
+	policy := RetryPolicy{BaseDelay: time.Millisecond}
+	s.Equal(2*time.Second, policy.delay(1, &HTTPStatusError{StatusCode: http.StatusServiceUnavailable, RetryAfter: 2 * time.Second}))
+	s.Equal(time.Millisecond, policy.delay(1, &HTTPStatusError{StatusCode: http.StatusInternalServerError, RetryAfter: 2 * time.Second}))
+}
+
+func (s *RetrySuite) TestIsRetryable() {
+	policy := RetryPolicy{}
+	s.True(policy.isRetryable(&HTTPStatusError{StatusCode: http.StatusBadGateway}))
+	s.False(policy.isRetryable(&HTTPStatusError{StatusCode: http.StatusForbidden}))
+	s.True(policy.isRetryable(io.ErrUnexpectedEOF))
+	s.True(policy.isRetryable(fakeTimeoutError{}))
+	s.False(policy.isRetryable(context.Canceled))
+	s.False(policy.isRetryable(errors.New("Field: Region, not set")))
+
+	policy.RetryableStatusCodes = []int{http.StatusForbidden}
+	s.True(policy.isRetryable(&HTTPStatusError{StatusCode: http.StatusForbidden}))
+	s.False(policy.isRetryable(&HTTPStatusError{StatusCode: http.StatusBadGateway}))
+}
@@ -0,0 +1,136 @@
+package remoteconfig
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+var (
+	rulesMutex sync.RWMutex
+	rules      = map[reflect.Type][]*expr{}
+)
+
+// Registers a struct-level validation rule for the type of sample, with self set to the struct being validated, i.e.
+//
+//	RegisterRule(S3Config{}, `self.Expiry <= 604800 || self.Endpoint != nil`)
+//
+// Rules are checked whenever a struct of that type is walked by the validator, and are reported as a ValidationError
+// with the VALIDATION_RULE_EXPR rule. As rules are plain strings they can be loaded alongside the config,
+// registering a rule that is already registered for the type does nothing.
+// Rules can be removed with UnregisterRule, or a type's rules replaced with SetRules.
+// The same expressions can be used on a field with the expr tag, i.e. `remoteconfig:"expr=self > 0 && self < parent.Max"`,
+// where self is the field and parent the struct holding it.
+//
+// Expressions support:
+//   - self and parent
+//   - exported field access by Go or json name, i.e. self.Expiry or self.expiry, a nil pointer gives nil,
+//     as does a field promoted through a nil embedded pointer
+//   - number, "string", true, false and nil literals
+//   - len(x) for strings, slices and maps
+//   - ! and unary -, * /, + -, < <= > >=, == !=, && and || with the usual precedence, and parentheses
+//
+// Numbers are compared as float64 and named string types as strings, i.e. self.Region == "us-east-1".
+func RegisterRule(sample interface{}, expression string) error {
+	t, err := ruleType(sample)
+	if err != nil {
+		return err
+	}
+
+	e, err := parseExpr(expression)
+	if err != nil {
+		return fmt.Errorf("Failed to parse rule '%s', with error, %w", expression, err)
+	}
+
+	rulesMutex.Lock()
+	defer rulesMutex.Unlock()
+	for _, registered := range rules[t] {
+		if registered.source == expression {
+			return nil
+		}
+	}
+	rules[t] = append(rules[t], e)
+	return nil
+}
+
+// Removes a rule registered for the type of sample with RegisterRule, removing one that isn't registered does nothing.
+func UnregisterRule(sample interface{}, expression string) error {
+	t, err := ruleType(sample)
+	if err != nil {
+		return err
+	}
+
+	rulesMutex.Lock()
+	defer rulesMutex.Unlock()
+	// The slice is replaced rather than changed in place, as validations may be reading it
+	remaining := []*expr{}
+	for _, registered := range rules[t] {
+		if registered.source != expression {
+			remaining = append(remaining, registered)
+		}
+	}
+	rules[t] = remaining
+	return nil
+}
+
+// Replaces the rules registered for the type of sample, i.e. with a rule set reloaded alongside the config.
+// When an expression fails to parse its error is returned and the registered rules are left as they were.
+// No expressions removes every rule for the type.
+func SetRules(sample interface{}, expressions ...string) error {
+	t, err := ruleType(sample)
+	if err != nil {
+		return err
+	}
+
+	parsed := []*expr{}
+	seen := map[string]bool{}
+	for _, expression := range expressions {
+		if seen[expression] {
+			continue
+		}
+		seen[expression] = true
+
+		e, err := parseExpr(expression)
+		if err != nil {
+			return fmt.Errorf("Failed to parse rule '%s', with error, %w", expression, err)
+		}
+		parsed = append(parsed, e)
+	}
+
+	rulesMutex.Lock()
+	defer rulesMutex.Unlock()
+	rules[t] = parsed
+	return nil
+}
+
+// Returns the struct type rules for sample are registered against, following pointers.
+func ruleType(sample interface{}) (reflect.Type, error) {
+	t := reflect.TypeOf(sample)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, errors.New("Rules can only be registered for struct types")
+	}
+	return t, nil
+}
+
+// Returns the rules registered for a struct type.
+func getRules(t reflect.Type) []*expr {
+	rulesMutex.RLock()
+	defer rulesMutex.RUnlock()
+	return rules[t]
+}
+
+// Evaluates an expression rule, returning an error describing why it failed.
+func checkExpr(e *expr, self reflect.Value, parent reflect.Value) error {
+	ok, err := e.eval(self, parent)
+	if err != nil {
+		return fmt.Errorf("failed to evaluate %s, with error, %w", e, err)
+	}
+	if !ok {
+		return fmt.Errorf("must satisfy %s", e)
+	}
+	return nil
+}
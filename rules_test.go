@@ -0,0 +1,152 @@
+package remoteconfig
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type RulesSuite struct {
+	suite.Suite
+}
+
+func TestRulesSuite(t *testing.T) {
+	suite.Run(t, new(RulesSuite))
+}
+
+type RuleSampleConfig struct {
+	Expiry   *uint   `json:"expiry"`
+	Endpoint *string `json:"endpoint" remoteconfig:"optional"`
+}
+
+type RuleParentConfig struct {
+	Max      int                 `json:"max"`
+	Current  int                 `json:"current" remoteconfig:"expr=self >= 0 && self <= parent.Max"`
+	Children []*RuleSampleConfig `json:"children"`
+}
+
+func (s *RulesSuite) TestRegisterRule() {
+	s.Nil(RegisterRule(&RuleSampleConfig{}, `self.Expiry <= 604800 || self.Endpoint != nil`))
+	s.Nil(RegisterRule(RuleSampleConfig{}, `self.Expiry <= 604800 || self.Endpoint != nil`))
+	s.Len(getRules(reflect.TypeOf(RuleSampleConfig{})), 1)
+
+	c := &RuleSampleConfig{}
+	s.Nil(ReadJSONValidate(bytes.NewBufferString(`{"expiry": 604801, "endpoint": "http://localhost"}`), c))
+
+	c = &RuleSampleConfig{}
+	err := ReadJSONValidate(bytes.NewBufferString(`{"expiry": 604801}`), c)
+	s.EqualError(err, "Field: RuleSampleConfig, must satisfy self.Expiry <= 604800 || self.Endpoint != nil")
+
+	validationErr := err.(*ValidationError)
+	s.Equal(VALIDATION_RULE_EXPR, validationErr.Rule)
+	s.Equal(`self.Expiry <= 604800 || self.Endpoint != nil`, validationErr.Param)
+	s.Equal(c, validationErr.Value)
+
+	// Rules apply wherever the type is found in a config
+	p := &RuleParentConfig{}
+	err = ReadValidate(bytes.NewBufferString(`{"max": 1, "current": 1, "children": [{"expiry": 60}, {"expiry": 604801}]}`), FORMAT_JSON, p, WithAllValidationErrors())
+	s.EqualError(err, "Field: Children[1], must satisfy self.Expiry <= 604800 || self.Endpoint != nil")
+	s.Equal("children[1]", err.(ValidationErrors)[0].(*ValidationError).JSONPath)
+}
+
+type RuleInnerConfig struct {
+	A *string `json:"a,omitempty" remoteconfig:"optional"`
+}
+
+type RuleEmbeddedConfig struct {
+	*RuleInnerConfig `remoteconfig:"optional"`
+	B                *string `json:"b,omitempty" remoteconfig:"optional,expr=self == nil || parent.A != nil"`
+}
+
+type RuleEmbeddedSelfConfig struct {
+	*RuleInnerConfig `remoteconfig:"optional"`
+	B                *string `json:"b,omitempty" remoteconfig:"optional"`
+}
+
+func (s *RulesSuite) TestRulesNilEmbeddedPointer() {
+	// A field promoted through a nil embedded pointer is nil, for parent in an expr tag and self in a registered rule
+	s.Nil(ReadJSONValidate(bytes.NewBufferString(`{}`), &RuleEmbeddedConfig{}))
+	s.Nil(ReadJSONValidate(bytes.NewBufferString(`{"a": "x", "b": "y"}`), &RuleEmbeddedConfig{}))
+	err := ReadJSONValidate(bytes.NewBufferString(`{"b": "y"}`), &RuleEmbeddedConfig{})
+	s.EqualError(err, "Field: B, must satisfy self == nil || parent.A != nil")
+
+	s.Nil(RegisterRule(RuleEmbeddedSelfConfig{}, `self.A != nil || self.B == nil`))
+	s.Nil(ReadJSONValidate(bytes.NewBufferString(`{}`), &RuleEmbeddedSelfConfig{}))
+	err = ReadJSONValidate(bytes.NewBufferString(`{"b": "y"}`), &RuleEmbeddedSelfConfig{})
+	s.EqualError(err, "Field: RuleEmbeddedSelfConfig, must satisfy self.A != nil || self.B == nil")
+}
+
+type RuleReplacedConfig struct {
+	Count int `json:"count"`
+}
+
+func (s *RulesSuite) TestUnregisterRule() {
+	s.Nil(RegisterRule(RuleReplacedConfig{}, `self.Count > 1`))
+	s.Nil(RegisterRule(RuleReplacedConfig{}, `self.Count < 10`))
+	s.EqualError(ReadJSONValidate(bytes.NewBufferString(`{"count": 1}`), &RuleReplacedConfig{}),
+		"Field: RuleReplacedConfig, must satisfy self.Count > 1")
+
+	s.Nil(UnregisterRule(&RuleReplacedConfig{}, `self.Count > 1`))
+	s.Nil(UnregisterRule(RuleReplacedConfig{}, `self.Count > 100`))
+	s.Len(getRules(reflect.TypeOf(RuleReplacedConfig{})), 1)
+	s.Nil(ReadJSONValidate(bytes.NewBufferString(`{"count": 1}`), &RuleReplacedConfig{}))
+
+	s.Nil(UnregisterRule(RuleReplacedConfig{}, `self.Count < 10`))
+	s.Len(getRules(reflect.TypeOf(RuleReplacedConfig{})), 0)
+	s.EqualError(UnregisterRule("", `self == ""`), "Rules can only be registered for struct types")
+}
+
+func (s *RulesSuite) TestSetRules() {
+	defer SetRules(RuleReplacedConfig{})
+
+	s.Nil(SetRules(RuleReplacedConfig{}, `self.Count > 1`, `self.Count > 1`))
+	s.Len(getRules(reflect.TypeOf(RuleReplacedConfig{})), 1)
+	s.EqualError(ReadJSONValidate(bytes.NewBufferString(`{"count": 1}`), &RuleReplacedConfig{}),
+		"Field: RuleReplacedConfig, must satisfy self.Count > 1")
+
+	// A rule set that fails to parse leaves the registered rules in place
+	s.EqualError(SetRules(RuleReplacedConfig{}, `self.Count < 10`, `self.Count >`),
+		"Failed to parse rule 'self.Count >', with error, unexpected 'end of expression' at position 12")
+	s.Equal(`self.Count > 1`, getRules(reflect.TypeOf(RuleReplacedConfig{}))[0].String())
+
+	s.Nil(SetRules(&RuleReplacedConfig{}, `self.Count < 10`))
+	s.Nil(ReadJSONValidate(bytes.NewBufferString(`{"count": 1}`), &RuleReplacedConfig{}))
+	s.EqualError(ReadJSONValidate(bytes.NewBufferString(`{"count": 10}`), &RuleReplacedConfig{}),
+		"Field: RuleReplacedConfig, must satisfy self.Count < 10")
+
+	s.Nil(SetRules(RuleReplacedConfig{}))
+	s.Nil(ReadJSONValidate(bytes.NewBufferString(`{"count": 10}`), &RuleReplacedConfig{}))
+	s.EqualError(SetRules(nil), "Rules can only be registered for struct types")
+}
+
+func (s *RulesSuite) TestRegisterRuleErrors() {
+	s.EqualError(RegisterRule("", `self == ""`), "Rules can only be registered for struct types")
+	s.EqualError(RegisterRule(nil, `self == nil`), "Rules can only be registered for struct types")
+	s.EqualError(RegisterRule(RuleSampleConfig{}, `self.Expiry >`), "Failed to parse rule 'self.Expiry >', with error, unexpected 'end of expression' at position 13")
+}
+
+func (s *RulesSuite) TestExprTag() {
+	c := &RuleParentConfig{}
+	err := ReadJSONValidate(bytes.NewBufferString(`{"max": 5, "current": 6, "children": [{"expiry": 60}]}`), c)
+	s.EqualError(err, "Field: Current, must satisfy self >= 0 && self <= parent.Max")
+	s.Equal(VALIDATION_RULE_EXPR, err.(*ValidationError).Rule)
+
+	c = &RuleParentConfig{}
+	s.Nil(ReadJSONValidate(bytes.NewBufferString(`{"max": 5, "current": 5, "children": [{"expiry": 60}]}`), c))
+}
+
+func (s *RulesSuite) TestExprTagInvalid() {
+	type InvalidExprConfig struct {
+		Count int `json:"count" remoteconfig:"expr=self >"`
+	}
+	err := validateConfigWithReflection(&InvalidExprConfig{})
+	s.EqualError(err, "Field: Count, invalid expr rule parameter 'self >', with error, unexpected 'end of expression' at position 6")
+
+	type FailingExprConfig struct {
+		Count int `json:"count" remoteconfig:"expr=self.Missing"`
+	}
+	err = validateConfigWithReflection(&FailingExprConfig{})
+	s.EqualError(err, "Field: Count, failed to evaluate self.Missing, with error, cannot access field Missing of number")
+}
@@ -3,17 +3,22 @@ package remoteconfig
 import (
 	"errors"
 	"net/url"
+	"strings"
 )
 
 const (
 	S3_CONFIG_DEFAULT_EXPIRY uint = 60
 )
 
+var (
+	ErrS3URLKeyNotSet = errors.New("S3 URL key not set")
+)
+
 type S3Config struct {
 	Endpoint *string    `json:"endpoint,omitempty" yaml:"endpoint,omitempty" remoteconfig:"optional"`
-	Bucket   *string    `json:"bucket,omitempty" yaml:"bucket,omitempty"`                         // i.e. bucket
-	Region   *AWSRegion `json:"region,omitempty" yaml:"region,omitempty"`                         // i.e. us-west-2
-	Expiry   *uint      `json:"expiry,omitempty" yaml:"expiry,omitempty" remoteconfig:"optional"` // i.e. 60
+	Bucket   *string    `json:"bucket,omitempty" yaml:"bucket,omitempty"`                                                     // i.e. bucket
+	Region   *AWSRegion `json:"region,omitempty" yaml:"region,omitempty"`                                                     // i.e. us-west-2
+	Expiry   *uint      `json:"expiry,omitempty" yaml:"expiry,omitempty" remoteconfig:"optional,default=60,min=1,max=604800"` // i.e. 60
 }
 
 func (c S3Config) GetEndpoint() string {
@@ -43,7 +48,12 @@ func S3URLToConfig(s3URL string) (*S3Config, string, error) {
 		return nil, "", errors.New("URL does not have the s3:// scheme")
 	}
 
+	key := strings.TrimPrefix(pURL.Path, "/")
+	if key == "" {
+		return nil, "", ErrS3URLKeyNotSet
+	}
+
 	c.Bucket = &pURL.Host
 
-	return c, pURL.Path[1:], nil
+	return c, key, nil
 }
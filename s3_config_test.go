@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -65,6 +66,26 @@ func (s *S3ConfigSuite) TestValidateWithOptional() {
 	s.Nil(err)
 }
 
+func (s *S3ConfigSuite) TestValidateErrorExpiry() {
+	bucket := VALID_S3_CONFIG_BUCKET
+	region := VALID_S3_CONFIG_REGION
+
+	for expiry, msg := range map[uint]string{
+		0:      "Field: Expiry, must be at least 1",
+		604801: "Field: Expiry, must be at most 604800",
+	} {
+		expiry := expiry
+		c := &S3Config{
+			Bucket: &bucket,
+			Region: &region,
+			Expiry: &expiry,
+		}
+
+		err := validateConfigWithReflection(c)
+		s.EqualError(err, msg)
+	}
+}
+
 func (s *S3ConfigSuite) TestValidateErrorEndpoint() {
 	endpoint := ""
 
@@ -74,7 +95,7 @@ func (s *S3ConfigSuite) TestValidateErrorEndpoint() {
 
 	err := validateConfigWithReflection(c)
 	s.NotNil(err)
-	s.Equal(errors.New("String Field: Endpoint, contains an empty string"), err)
+	s.EqualError(err, "String Field: Endpoint, contains an empty string")
 }
 
 func (s *S3ConfigSuite) TestValidateErrorBucketNotSet() {
@@ -84,7 +105,7 @@ func (s *S3ConfigSuite) TestValidateErrorBucketNotSet() {
 
 	err := validateConfigWithReflection(c)
 	s.NotNil(err)
-	s.Equal(errors.New("Field: Bucket, not set"), err)
+	s.EqualError(err, "Field: Bucket, not set")
 }
 
 func (s *S3ConfigSuite) TestValidateErrorBucketEmpty() {
@@ -95,7 +116,7 @@ func (s *S3ConfigSuite) TestValidateErrorBucketEmpty() {
 
 	err := validateConfigWithReflection(c)
 	s.NotNil(err)
-	s.Equal(errors.New("String Field: Bucket, contains an empty string"), err)
+	s.EqualError(err, "String Field: Bucket, contains an empty string")
 }
 
 func (s *S3ConfigSuite) TestValidateErrorRegionNotSet() {
@@ -108,7 +129,7 @@ func (s *S3ConfigSuite) TestValidateErrorRegionNotSet() {
 
 	err := validateConfigWithReflection(c)
 	s.NotNil(err)
-	s.Equal(errors.New("Field: Region, not set"), err)
+	s.EqualError(err, "Field: Region, not set")
 }
 
 func (s *S3ConfigSuite) TestValidateErrorRegionInvalid() {
@@ -122,7 +143,27 @@ func (s *S3ConfigSuite) TestValidateErrorRegionInvalid() {
 
 	err := validateConfigWithReflection(c)
 	s.NotNil(err)
-	s.Equal(errors.New("Validater Field: Region, failed to validate with error, Region is invalid"), err)
+	s.EqualError(err, "Validater Field: Region, failed to validate with error, Region is invalid")
+}
+
+func (s *S3ConfigSuite) TestReadYAMLValidate() {
+	cfg := "endpoint: http://localhost:9500/s3\nbucket: bucket\nregion: us-west-2\nexpiry: 30\n"
+
+	c := &S3Config{}
+	err := ReadYAMLValidate(strings.NewReader(cfg), c)
+	s.Nil(err)
+	s.Equal(VALID_S3_CONFIG_ENDPOINT, c.GetEndpoint())
+	s.Equal(VALID_S3_CONFIG_BUCKET, *c.Bucket)
+	s.Equal(VALID_S3_CONFIG_REGION, *c.Region)
+	s.Equal(VALID_S3_CONFIG_EXPIRY, c.GetExpiry())
+}
+
+func (s *S3ConfigSuite) TestReadYAMLValidateErrorRegionInvalid() {
+	cfg := "bucket: bucket\nregion: invalidregion\n"
+
+	err := ReadYAMLValidate(strings.NewReader(cfg), &S3Config{})
+	s.NotNil(err)
+	s.Contains(err.Error(), "Region is invalid")
 }
 
 func (s *S3ConfigSuite) TestGetEndpointNotSet() {
@@ -168,3 +209,12 @@ func (s *S3ConfigSuite) TestS3URLToConfigErrorURLScheme() {
 	s.NotNil(err)
 	s.Equal(errors.New("URL does not have the s3:// scheme"), err)
 }
+
+func (s *S3ConfigSuite) TestS3URLToConfigErrorKeyNotSet() {
+	for _, s3URL := range []string{"s3://bucket", "s3://bucket/"} {
+		s3ConfigURL, path, err := S3URLToConfig(s3URL)
+		s.Nil(s3ConfigURL)
+		s.Empty(path)
+		s.Equal(ErrS3URLKeyNotSet, err)
+	}
+}
@@ -6,7 +6,7 @@ const (
 
 type S3EndpointExpiryConfig struct {
 	Endpoint *string `json:"endpoint,omitempty" yaml:"endpoint,omitempty" remoteconfig:"optional"`
-	Expiry   *uint   `json:"expiry,omitempty" yaml:"expiry,omitempty" remoteconfig:"optional"`
+	Expiry   *uint   `json:"expiry,omitempty" yaml:"expiry,omitempty" remoteconfig:"optional,default=60,min=1,max=604800"`
 }
 
 func (c S3EndpointExpiryConfig) GetEndpoint() string {
@@ -1,7 +1,6 @@
 package remoteconfig
 
 import (
-	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -49,7 +48,7 @@ func (s *S3EndpointExpiryConfigSuite) TestValidateErrorEndpoint() {
 
 	err := validateConfigWithReflection(c)
 	assert.NotNil(s.T(), err)
-	assert.Equal(s.T(), errors.New("String Field: Endpoint, contains an empty string"), err)
+	assert.EqualError(s.T(), err, "String Field: Endpoint, contains an empty string")
 }
 
 func (s *S3EndpointExpiryConfigSuite) TestGetExpirySet() {
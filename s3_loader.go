@@ -0,0 +1,120 @@
+package remoteconfig
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+var (
+	ErrS3ConfigBucketNotSet = errors.New("S3 config bucket not set")
+	ErrS3ConfigRegionNotSet = errors.New("S3 config region not set")
+)
+
+// Downloads a configuration file from S3 with a SigV4 signed GetObject request.
+// Parses it to a particular struct type and runs a validation.
+// The format is picked from the object's Content-Type or the key's extension, defaulting to JSON.
+// The bucket, region and optional endpoint are taken from the S3Config, see S3URLToConfig
+// for splitting an s3://bucket/path/file.json URL into a config and key.
+func LoadConfigFromS3(s3Config *S3Config, key string, creds AWSCredentials, configStruct interface{}) error {
+	return LoadConfigFromS3WithContext(context.Background(), s3Config, key, creds, configStruct)
+}
+
+// Same as LoadConfigFromS3, the download and decode stop when the context is done.
+// A TimeoutError is returned when the deadline is exceeded.
+func LoadConfigFromS3WithContext(ctx context.Context, s3Config *S3Config, key string, creds AWSCredentials, configStruct interface{}) error {
+	resp, err := getS3Object(ctx, http.DefaultClient, s3Config, key, creds, nil, nil)
+	if err != nil {
+		return wrapContextError(err)
+	}
+	defer resp.Body.Close()
+
+	format := detectFormat("", resp.Header.Get("Content-Type"), key)
+	return ReadValidateWithContext(ctx, resp.Body, format, configStruct)
+}
+
+// Runs a GetObject request with extra request headers, the response body must be closed by the caller.
+// With previous metadata the request is conditional, ErrNotModified is returned when the object hasn't changed.
+func getS3Object(ctx context.Context, client *http.Client, s3Config *S3Config, key string, creds AWSCredentials, header http.Header, previous *SourceMetadata) (*http.Response, error) {
+	req, err := newS3GetObjectRequest(s3Config, key, creds, header, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	// The conditional headers aren't part of the SigV4 signature
+	setConditionalHeaders(req, previous)
+
+	resp, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusNotModified {
+		resp.Body.Close()
+		return nil, ErrNotModified
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, newHTTPStatusError(req.URL.String(), resp)
+	}
+
+	return resp, nil
+}
+
+// Builds a GetObject request for the key, signed if credentials are provided.
+// The extra headers are set before signing, so x-amz-* headers are signed and an Authorization header is replaced.
+func newS3GetObjectRequest(s3Config *S3Config, key string, creds AWSCredentials, header http.Header, t time.Time) (*http.Request, error) {
+	objectURL, err := s3ObjectURL(s3Config, key)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodGet, objectURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range header {
+		req.Header[k] = append([]string{}, v...)
+	}
+
+	if !creds.IsAnonymous() {
+		signAWSRequestV4(req, creds, *s3Config.Region, "s3", AWS_EMPTY_PAYLOAD_HASH, t)
+	}
+
+	return req, nil
+}
+
+// Returns the URL of an object.
+// Uses a path style URL when a custom endpoint is set, otherwise the regional virtual hosted style URL.
+func s3ObjectURL(s3Config *S3Config, key string) (*url.URL, error) {
+	if s3Config == nil || s3Config.Bucket == nil || *s3Config.Bucket == "" {
+		return nil, ErrS3ConfigBucketNotSet
+	}
+	if s3Config.Region == nil || *s3Config.Region == "" {
+		return nil, ErrS3ConfigRegionNotSet
+	}
+
+	key = strings.TrimPrefix(key, "/")
+
+	var base string
+	var path string
+	if endpoint := s3Config.GetEndpoint(); endpoint != "" {
+		base = strings.TrimSuffix(endpoint, "/")
+		path = "/" + *s3Config.Bucket + "/" + key
+	} else {
+		base = fmt.Sprintf("https://%s.s3.%s.amazonaws.com", *s3Config.Bucket, *s3Config.Region)
+		path = "/" + key
+	}
+
+	u, err := url.Parse(base)
+	if err != nil {
+		return nil, err
+	}
+	u.Path = strings.TrimSuffix(u.Path, "/") + path
+	u.RawPath = awsURIEncode(u.Path, false)
+
+	return u, nil
+}
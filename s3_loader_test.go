@@ -0,0 +1,166 @@
+package remoteconfig
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type S3LoaderSuite struct {
+	suite.Suite
+	creds AWSCredentials
+}
+
+func TestS3LoaderSuite(t *testing.T) {
+	suite.Run(t, new(S3LoaderSuite))
+}
+
+func (s *S3LoaderSuite) SetupTest() {
+	s.creds = AWSCredentials{
+		AccessKeyID:     VALID_SIGV4_ACCESS_KEY_ID,
+		SecretAccessKey: VALID_SIGV4_SECRET_ACCESS_KEY,
+	}
+}
+
+// Returns a fake S3 server which checks the request signature before serving the body
+func (s *S3LoaderSuite) newSigningS3Server(expectedPath string, body string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.EscapedPath() != expectedPath {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		t, err := time.Parse(AWS_SIGV4_TIME_FORMAT, r.Header.Get("X-Amz-Date"))
+		if err != nil {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		expected, _ := http.NewRequest(r.Method, "http://"+r.Host+r.URL.RequestURI(), nil)
+		signAWSRequestV4(expected, s.creds, VALID_S3_CONFIG_REGION, "s3", r.Header.Get("X-Amz-Content-Sha256"), t)
+		if expected.Header.Get("Authorization") != r.Header.Get("Authorization") {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		fmt.Fprintln(w, body)
+	}))
+}
+
+func (s *S3LoaderSuite) buildS3Config(endpoint string) *S3Config {
+	bucket := VALID_S3_CONFIG_BUCKET
+	region := VALID_S3_CONFIG_REGION
+	return &S3Config{
+		Endpoint: &endpoint,
+		Bucket:   &bucket,
+		Region:   &region,
+	}
+}
+
+func (s *S3LoaderSuite) TestLoadConfigFromS3() {
+	ts := s.newSigningS3Server("/bucket/test/path.json", validConfigJSON)
+	defer ts.Close()
+
+	c := &SampleConfig{}
+	err := LoadConfigFromS3(s.buildS3Config(ts.URL), "test/path.json", s.creds, c)
+	s.Nil(err)
+	s.Equal("testStr", c.Str)
+}
+
+func (s *S3LoaderSuite) TestLoadConfigFromS3URL() {
+	ts := s.newSigningS3Server("/bucket/test/path.json", validConfigJSON)
+	defer ts.Close()
+
+	s3Config, key, err := S3URLToConfig("s3://bucket/test/path.json")
+	s.Nil(err)
+	region := VALID_S3_CONFIG_REGION
+	s3Config.Region = &region
+	s3Config.Endpoint = &ts.URL
+
+	c := &SampleConfig{}
+	err = LoadConfigFromS3(s3Config, key, s.creds, c)
+	s.Nil(err)
+}
+
+func (s *S3LoaderSuite) TestLoadConfigFromS3FormatFromKey() {
+	ts := s.newSigningS3Server("/bucket/test/path.yaml", validConfigYAML)
+	defer ts.Close()
+
+	c := &SampleConfig{}
+	err := LoadConfigFromS3(s.buildS3Config(ts.URL), "test/path.yaml", s.creds, c)
+	s.Nil(err)
+	s.Equal("testStr", c.Str)
+}
+
+func (s *S3LoaderSuite) TestLoadConfigFromS3ErrorSignature() {
+	ts := s.newSigningS3Server("/bucket/test/path.json", validConfigJSON)
+	defer ts.Close()
+
+	creds := s.creds
+	creds.SecretAccessKey = "wrong"
+
+	c := &SampleConfig{}
+	err := LoadConfigFromS3(s.buildS3Config(ts.URL), "test/path.json", creds, c)
+	s.NotNil(err)
+	s.Equal(fmt.Sprintf("Request to '%s/bucket/test/path.json' returned non-200 OK status '403: Forbidden'", ts.URL), err.Error())
+}
+
+func (s *S3LoaderSuite) TestLoadConfigFromS3ErrorValidation() {
+	ts := s.newSigningS3Server("/bucket/test/path.json", "{}")
+	defer ts.Close()
+
+	c := &SQSQueueConfig{}
+	err := LoadConfigFromS3(s.buildS3Config(ts.URL), "test/path.json", s.creds, c)
+	s.NotNil(err)
+	s.Equal("Field: Region, not set", err.Error())
+}
+
+func (s *S3LoaderSuite) TestLoadConfigFromS3WithContextTimeout() {
+	unblock := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-unblock
+	}))
+	defer ts.Close()
+	defer close(unblock)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	err := LoadConfigFromS3WithContext(ctx, s.buildS3Config(ts.URL), "test/path.json", s.creds, &SampleConfig{})
+	s.NotNil(err)
+	s.True(errors.Is(err, ErrTimeout))
+}
+
+func (s *S3LoaderSuite) TestLoadConfigFromS3ErrorBucketNotSet() {
+	region := VALID_S3_CONFIG_REGION
+	err := LoadConfigFromS3(&S3Config{Region: &region}, "test/path.json", s.creds, &SampleConfig{})
+	s.Equal(ErrS3ConfigBucketNotSet, err)
+}
+
+func (s *S3LoaderSuite) TestLoadConfigFromS3ErrorRegionNotSet() {
+	bucket := VALID_S3_CONFIG_BUCKET
+	err := LoadConfigFromS3(&S3Config{Bucket: &bucket}, "test/path.json", s.creds, &SampleConfig{})
+	s.Equal(ErrS3ConfigRegionNotSet, err)
+}
+
+func (s *S3LoaderSuite) TestS3ObjectURL() {
+	u, err := s3ObjectURL(s.buildS3Config(""), "test/path.json")
+	s.Nil(err)
+	s.Equal("https://bucket.s3.us-west-2.amazonaws.com/test/path.json", u.String())
+
+	u, err = s3ObjectURL(s.buildS3Config(VALID_S3_CONFIG_ENDPOINT), "/test/a file.json")
+	s.Nil(err)
+	s.Equal("http://localhost:9500/s3/bucket/test/a%20file.json", u.String())
+}
+
+func (s *S3LoaderSuite) TestNewS3GetObjectRequestAnonymous() {
+	req, err := newS3GetObjectRequest(s.buildS3Config(""), "test/path.json", AWSCredentials{}, nil, time.Now())
+	s.Nil(err)
+	s.Empty(req.Header.Get("Authorization"))
+}
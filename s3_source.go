@@ -0,0 +1,96 @@
+package remoteconfig
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+)
+
+// Source for s3://bucket/path/file.json URLs.
+// When not set, the region is read from the AWS_REGION or AWS_DEFAULT_REGION environment variables
+// and the credentials from the standard AWS credential environment variables.
+type S3Source struct {
+	Region      AWSRegion
+	Endpoint    string
+	Credentials *AWSCredentials
+	Client      *http.Client // Defaults to http.DefaultClient
+	Header      http.Header  // Extra request headers, an Authorization header is replaced by the signature on signed requests
+	UserAgent   string
+}
+
+func (s *S3Source) Open(ctx context.Context, location *url.URL) (io.ReadCloser, *SourceMetadata, error) {
+	return s.OpenIfModified(ctx, location, nil)
+}
+
+// Sends If-None-Match and If-Modified-Since from the previous metadata, an unchanged object returns ErrNotModified.
+func (s *S3Source) OpenIfModified(ctx context.Context, location *url.URL, previous *SourceMetadata) (io.ReadCloser, *SourceMetadata, error) {
+	s3Config, key, err := S3URLToConfig(location.String())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	region := s.getRegion()
+	endpoint := s.Endpoint
+	s3Config.Region = &region
+	s3Config.Endpoint = &endpoint
+
+	header := http.Header{}
+	for k, v := range s.Header {
+		header[k] = v
+	}
+	if s.UserAgent != "" {
+		header.Set("User-Agent", s.UserAgent)
+	}
+
+	resp, err := getS3Object(ctx, s.getClient(), s3Config, key, s.getCredentials(), header, previous)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return resp.Body, responseMetadata(resp), nil
+}
+
+func (s *S3Source) getRegion() AWSRegion {
+	if s.Region != "" {
+		return s.Region
+	}
+	if region := os.Getenv("AWS_REGION"); region != "" {
+		return AWSRegion(region)
+	}
+	return AWSRegion(os.Getenv("AWS_DEFAULT_REGION"))
+}
+
+func (s *S3Source) getCredentials() AWSCredentials {
+	if s.Credentials != nil {
+		return *s.Credentials
+	}
+	return AWSCredentialsFromEnv()
+}
+
+func (s *S3Source) getClient() *http.Client {
+	if s.Client != nil {
+		return s.Client
+	}
+	return http.DefaultClient
+}
+
+// Returns a copy of the source with the HTTP load options applied on top.
+func (s *S3Source) withOptions(o *loadOptions) *S3Source {
+	source := *s
+	source.Header = http.Header{}
+	for k, v := range s.Header {
+		source.Header[k] = v
+	}
+	for k, v := range o.header {
+		source.Header[k] = v
+	}
+	if o.client != nil {
+		source.Client = o.client
+	}
+	if o.userAgent != "" {
+		source.UserAgent = o.userAgent
+	}
+	return &source
+}
@@ -0,0 +1,113 @@
+package remoteconfig
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type S3SourceSuite struct {
+	suite.Suite
+	creds AWSCredentials
+}
+
+func TestS3SourceSuite(t *testing.T) {
+	suite.Run(t, new(S3SourceSuite))
+}
+
+func (s *S3SourceSuite) SetupTest() {
+	s.creds = AWSCredentials{
+		AccessKeyID:     VALID_SIGV4_ACCESS_KEY_ID,
+		SecretAccessKey: VALID_SIGV4_SECRET_ACCESS_KEY,
+	}
+}
+
+func (s *S3SourceSuite) TestOpen() {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.Equal("/bucket/test/path.json", r.URL.Path)
+		s.Contains(r.Header.Get("Authorization"), "/us-west-2/s3/aws4_request")
+		w.Header().Set("ETag", `"abc"`)
+		w.Header().Set("X-Amz-Version-Id", "v2")
+		w.Write([]byte(validConfigJSON))
+	}))
+	defer ts.Close()
+
+	source := &S3Source{Region: VALID_S3_CONFIG_REGION, Endpoint: ts.URL, Credentials: &s.creds}
+	location, _ := url.Parse("s3://bucket/test/path.json")
+	body, metadata, err := source.Open(context.Background(), location)
+	s.Nil(err)
+	defer body.Close()
+	s.Equal("v2", metadata.Version)
+	s.Equal(`"abc"`, metadata.ETag)
+}
+
+func (s *S3SourceSuite) TestOpenErrorNotFound() {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer ts.Close()
+
+	source := &S3Source{Region: VALID_S3_CONFIG_REGION, Endpoint: ts.URL, Credentials: &s.creds}
+	location, _ := url.Parse("s3://bucket/test/path.json")
+	body, _, err := source.Open(context.Background(), location)
+	s.Nil(body)
+	s.EqualError(err, "Request to '"+ts.URL+"/bucket/test/path.json' returned non-200 OK status '404: Not Found'")
+}
+
+func (s *S3SourceSuite) TestOpenWithOptions() {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.Equal("my-agent", r.Header.Get("User-Agent"))
+		s.Equal("requester", r.Header.Get("X-Amz-Request-Payer"))
+		s.Contains(r.Header.Get("Authorization"), "SignedHeaders=host;x-amz-content-sha256;x-amz-date;x-amz-request-payer,")
+		w.Write([]byte(validConfigJSON))
+	}))
+	defer ts.Close()
+
+	source := (&S3Source{Region: VALID_S3_CONFIG_REGION, Endpoint: ts.URL, Credentials: &s.creds}).withOptions(newLoadOptions([]LoadOption{
+		WithHeader("X-Amz-Request-Payer", "requester"),
+		WithUserAgent("my-agent"),
+	}))
+	location, _ := url.Parse("s3://bucket/test/path.json")
+	body, _, err := source.Open(context.Background(), location)
+	s.Nil(err)
+	body.Close()
+}
+
+func (s *S3SourceSuite) TestLoadErrorKeyNotSet() {
+	config := &SampleConfig{}
+	err := Load(context.Background(), "s3://bucket", config)
+	s.Equal(ErrS3URLKeyNotSet, err)
+}
+
+func (s *S3SourceSuite) TestGetRegionFromEnv() {
+	defer os.Setenv("AWS_REGION", os.Getenv("AWS_REGION"))
+	os.Setenv("AWS_REGION", "eu-west-1")
+
+	s.Equal(AWS_REGION_EU_WEST_1, (&S3Source{}).getRegion())
+	s.Equal(AWS_REGION_US_EAST_1, (&S3Source{Region: AWS_REGION_US_EAST_1}).getRegion())
+}
+
+func (s *S3SourceSuite) TestGetCredentials() {
+	s.Equal(s.creds, (&S3Source{Credentials: &s.creds}).getCredentials())
+}
+
+func (s *S3SourceSuite) TestOpenIfModified() {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.Contains(r.Header.Get("Authorization"), "/us-west-2/s3/aws4_request")
+		s.Equal(`"abc"`, r.Header.Get("If-None-Match"))
+		w.WriteHeader(http.StatusNotModified)
+	}))
+	defer ts.Close()
+
+	source := &S3Source{Region: VALID_S3_CONFIG_REGION, Endpoint: ts.URL, Credentials: &s.creds}
+	location, _ := url.Parse("s3://bucket/test/path.json")
+	body, metadata, err := source.OpenIfModified(context.Background(), location, &SourceMetadata{ETag: `"abc"`})
+	s.Nil(body)
+	s.Nil(metadata)
+	s.Equal(ErrNotModified, err)
+}
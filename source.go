@@ -0,0 +1,171 @@
+package remoteconfig
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// A Source opens config documents from a storage backend, i.e. HTTP, S3 or the local filesystem.
+// Sources are registered against a URL scheme with RegisterSource and picked by Load.
+// Open should return an error matching ErrNotFound or os.ErrNotExist with errors.Is when the document doesn't exist.
+type Source interface {
+	Open(ctx context.Context, location *url.URL) (io.ReadCloser, *SourceMetadata, error)
+}
+
+// Returned by a ConditionalSource when the document hasn't changed since it was last opened.
+var ErrNotModified = errors.New("Config not modified")
+
+// Implemented by sources that can skip sending a document that hasn't changed since it was last opened,
+// i.e. with an HTTP conditional request. Loader uses it to poll without downloading or decoding an unchanged document.
+type ConditionalSource interface {
+	Source
+	// Opens the document when it has changed since the previous metadata, otherwise returns ErrNotModified.
+	// A nil previous metadata opens the document unconditionally.
+	OpenIfModified(ctx context.Context, location *url.URL, previous *SourceMetadata) (io.ReadCloser, *SourceMetadata, error)
+}
+
+// Metadata about an opened config document, fields are empty when the backend doesn't provide them.
+type SourceMetadata struct {
+	Version      string
+	ETag         string
+	LastModified time.Time
+	ContentType  string
+}
+
+var (
+	sourcesMutex sync.RWMutex
+	sources      = map[string]Source{
+		"http":  &HTTPSource{},
+		"https": &HTTPSource{},
+		"file":  &FileSource{},
+		"s3":    &S3Source{},
+	}
+)
+
+// Registers a Source for a URL scheme, replacing any Source already registered for it.
+func RegisterSource(scheme string, source Source) {
+	sourcesMutex.Lock()
+	defer sourcesMutex.Unlock()
+	sources[strings.ToLower(scheme)] = source
+}
+
+// Returns the Source registered for a URL scheme.
+func GetSource(scheme string) (Source, error) {
+	sourcesMutex.RLock()
+	defer sourcesMutex.RUnlock()
+	source, ok := sources[strings.ToLower(scheme)]
+	if !ok {
+		return nil, fmt.Errorf("No source registered for scheme '%s'", scheme)
+	}
+	return source, nil
+}
+
+// Returns the Source registered for a storage provider's URL scheme.
+func GetStorageProviderSource(provider StorageProvider) (Source, error) {
+	if err := provider.Validate(); err != nil {
+		return nil, err
+	}
+	return GetSource(provider.Scheme())
+}
+
+// Loads a configuration file from a URL using the Source registered for its scheme.
+// Parses it to a particular struct type and runs a validation.
+// The format is picked from the Content-Type or the file extension unless set with WithFormat, defaulting to JSON.
+// The load stops when the context is done, a TimeoutError is returned when the deadline is exceeded.
+// HTTP load options apply to the built in HTTP and S3 sources, on signed S3 requests an Authorization header is replaced by the signature.
+func Load(ctx context.Context, configURL string, configStruct interface{}, opts ...LoadOption) error {
+	options := newLoadOptions(opts)
+	source, location, err := getURLSource(configURL, options)
+	if err != nil {
+		return err
+	}
+
+	return loadFromSource(ctx, source, location, configStruct, options)
+}
+
+// Returns the Source registered for the URL's scheme, with the HTTP load options applied to the built in sources.
+func getURLSource(configURL string, options *loadOptions) (Source, *url.URL, error) {
+	location, err := url.Parse(configURL)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	source, err := GetSource(location.Scheme)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	switch s := source.(type) {
+	case *HTTPSource:
+		source = s.withOptions(options)
+	case *S3Source:
+		source = s.withOptions(options)
+	}
+
+	return source, location, nil
+}
+
+// Opens the location with the source, then decodes and validates the document.
+// The format comes from the load options, the Content-Type or the location's file extension.
+// With a retry policy the body is downloaded in full on each attempt before decoding.
+func loadFromSource(ctx context.Context, source Source, location *url.URL, configStruct interface{}, options *loadOptions) error {
+	_, err := loadFromSourceIfModified(ctx, source, location, nil, configStruct, options)
+	return err
+}
+
+// Same as loadFromSource, when the source is a ConditionalSource and there's previous metadata the document
+// is only opened if it has changed, otherwise ErrNotModified is returned. Returns the metadata of the loaded document.
+func loadFromSourceIfModified(ctx context.Context, source Source, location *url.URL, previous *SourceMetadata, configStruct interface{}, options *loadOptions) (*SourceMetadata, error) {
+	open := source.Open
+	if conditional, ok := source.(ConditionalSource); ok && previous != nil {
+		open = func(ctx context.Context, location *url.URL) (io.ReadCloser, *SourceMetadata, error) {
+			return conditional.OpenIfModified(ctx, location, previous)
+		}
+	}
+
+	if options.retry == nil {
+		body, metadata, err := open(ctx, location)
+		if err != nil {
+			return nil, wrapSourceError(wrapContextError(err))
+		}
+		defer body.Close()
+
+		return metadata, readValidate(ctx, body, options.detectFormat(metadata, location), configStruct, options)
+	}
+
+	var data []byte
+	var metadata *SourceMetadata
+	err := options.retry.do(ctx, func() error {
+		body, m, err := open(ctx, location)
+		if err != nil {
+			return err
+		}
+		defer body.Close()
+
+		data, err = ioutil.ReadAll(&contextReader{ctx: ctx, reader: body})
+		metadata = m
+		return err
+	})
+	if err != nil {
+		return nil, wrapSourceError(err)
+	}
+
+	return metadata, readValidate(ctx, bytes.NewReader(data), options.detectFormat(metadata, location), configStruct, options)
+}
+
+// Marks an error from a source for a missing document as ErrNotFound, other errors are returned as is.
+func wrapSourceError(err error) error {
+	if errors.Is(err, os.ErrNotExist) && !errors.Is(err, ErrNotFound) {
+		return &notFoundError{err: err}
+	}
+	return err
+}
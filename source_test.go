@@ -0,0 +1,128 @@
+package remoteconfig
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type memorySource struct {
+	documents map[string]string
+}
+
+func (m *memorySource) Open(ctx context.Context, location *url.URL) (io.ReadCloser, *SourceMetadata, error) {
+	doc, ok := m.documents[location.Host+location.Path]
+	if !ok {
+		return nil, nil, fmt.Errorf("Document %s, %w", location, ErrNotFound)
+	}
+	return ioutil.NopCloser(bytes.NewBufferString(doc)), &SourceMetadata{Version: "1"}, nil
+}
+
+type SourceSuite struct {
+	suite.Suite
+}
+
+func TestSourceSuite(t *testing.T) {
+	suite.Run(t, new(SourceSuite))
+}
+
+func (s *SourceSuite) TearDownTest() {
+	sourcesMutex.Lock()
+	delete(sources, "memory")
+	sourcesMutex.Unlock()
+}
+
+func (s *SourceSuite) TestGetSourceBuiltIn() {
+	for _, scheme := range []string{"http", "https", "file", "s3", "HTTPS"} {
+		source, err := GetSource(scheme)
+		s.Nil(err)
+		s.NotNil(source)
+	}
+}
+
+func (s *SourceSuite) TestGetSourceErrorNotRegistered() {
+	source, err := GetSource("gcs")
+	s.Nil(source)
+	s.Equal(errors.New("No source registered for scheme 'gcs'"), err)
+}
+
+func (s *SourceSuite) TestRegisterSource() {
+	RegisterSource("Memory", &memorySource{documents: map[string]string{"configs/sample.json": validConfigJSON}})
+
+	c := &SampleConfig{}
+	err := Load(context.Background(), "memory://configs/sample.json", c)
+	s.Nil(err)
+	s.Equal("testStr", c.Str)
+}
+
+func (s *SourceSuite) TestLoadErrorOpen() {
+	RegisterSource("memory", &memorySource{})
+
+	err := Load(context.Background(), "memory://configs/sample.json", &SampleConfig{})
+	s.EqualError(err, "Document memory://configs/sample.json, Config not found")
+	s.True(errors.Is(err, ErrNotFound))
+}
+
+func (s *SourceSuite) TestLoadErrorNotRegistered() {
+	err := Load(context.Background(), "gcs://bucket/sample.json", &SampleConfig{})
+	s.Equal(errors.New("No source registered for scheme 'gcs'"), err)
+}
+
+func (s *SourceSuite) TestLoadErrorURLParse() {
+	err := Load(context.Background(), "invalid%6", &SampleConfig{})
+	s.NotNil(err)
+	s.IsType(&url.Error{}, err)
+}
+
+func (s *SourceSuite) TestLoadHTTP() {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, validConfigJSON)
+	}))
+	defer ts.Close()
+
+	c := &SampleConfig{}
+	err := Load(context.Background(), ts.URL, c)
+	s.Nil(err)
+}
+
+func (s *SourceSuite) TestLoadHTTPWithOptions() {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprintln(w, validConfigJSON)
+	}))
+	defer ts.Close()
+
+	err := Load(context.Background(), ts.URL, &SampleConfig{}, WithBearerToken("token"))
+	s.Nil(err)
+}
+
+func (s *SourceSuite) TestLoadErrorValidation() {
+	RegisterSource("memory", &memorySource{documents: map[string]string{"configs/sample.json": "{}"}})
+
+	err := Load(context.Background(), "memory://configs/sample.json", &SQSQueueConfig{})
+	s.EqualError(err, "Field: Region, not set")
+}
+
+func (s *SourceSuite) TestGetStorageProviderSource() {
+	source, err := GetStorageProviderSource(STORAGE_PROVIDER_AWS)
+	s.Nil(err)
+	s.IsType(&S3Source{}, source)
+}
+
+func (s *SourceSuite) TestGetStorageProviderSourceErrorInvalid() {
+	source, err := GetStorageProviderSource(StorageProvider("gcs"))
+	s.Nil(source)
+	s.Equal(errors.New("Invalid storage provider"), err)
+}
@@ -1,8 +1,8 @@
 package remoteconfig
 
 type SQSClientConfig struct {
-	Region   *AWSRegion `json:"region,omitempty"`
-	Endpoint *string    `json:"endpoint,omitempty" remoteconfig:"optional"`
+	Region   *AWSRegion `json:"region,omitempty" yaml:"region,omitempty"`
+	Endpoint *string    `json:"endpoint,omitempty" yaml:"endpoint,omitempty" remoteconfig:"optional"`
 }
 
 func (s SQSClientConfig) GetRegion() AWSRegion {
@@ -10,5 +10,8 @@ func (s SQSClientConfig) GetRegion() AWSRegion {
 }
 
 func (s SQSClientConfig) GetEndpoint() string {
-	return *s.Endpoint
+	if s.Endpoint != nil {
+		return *s.Endpoint
+	}
+	return ""
 }
@@ -1,7 +1,6 @@
 package remoteconfig
 
 import (
-	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -60,7 +59,7 @@ func (s *SQSClientConfigSuite) TestValidateErrorRegion() {
 
 	err := validateConfigWithReflection(c)
 	assert.NotNil(s.T(), err)
-	assert.Equal(s.T(), errors.New("Validater Field: Region, failed to validate with error, Region is invalid"), err)
+	assert.EqualError(s.T(), err, "Validater Field: Region, failed to validate with error, Region is invalid")
 }
 
 func (s *SQSClientConfigSuite) TestValidateErrorEndpoint() {
@@ -74,7 +73,7 @@ func (s *SQSClientConfigSuite) TestValidateErrorEndpoint() {
 
 	err := validateConfigWithReflection(c)
 	assert.NotNil(s.T(), err)
-	assert.Equal(s.T(), errors.New("String Field: Endpoint, contains an empty string"), err)
+	assert.EqualError(s.T(), err, "String Field: Endpoint, contains an empty string")
 }
 
 func (s *SQSClientConfigSuite) TestGetRegion() {
@@ -100,3 +99,13 @@ func (s *SQSClientConfigSuite) TestGetEndpoint() {
 	sEndpoint := c.GetEndpoint()
 	assert.Equal(s.T(), VALID_SQS_CLIENT_ENDPOINT, sEndpoint)
 }
+
+func (s *SQSClientConfigSuite) TestGetEndpointNotSet() {
+	region := VALID_SQS_CLIENT_REGION
+
+	c := &SQSClientConfig{
+		Region: &region,
+	}
+
+	assert.Equal(s.T(), "", c.GetEndpoint())
+}
@@ -3,9 +3,9 @@ package remoteconfig
 import "fmt"
 
 type SQSQueueConfig struct {
-	Region       *AWSRegion `json:"region,omitempty"`
-	AWSAccountID *string    `json:"aws_account_id,omitempty"`
-	QueueName    *string    `json:"queue_name,omitempty"`
+	Region       *AWSRegion `json:"region,omitempty" yaml:"region,omitempty"`
+	AWSAccountID *string    `json:"aws_account_id,omitempty" yaml:"aws_account_id,omitempty" remoteconfig:"regex=^[0-9]{12}$"`
+	QueueName    *string    `json:"queue_name,omitempty" yaml:"queue_name,omitempty"`
 }
 
 // Returns a full SQS queue URL.
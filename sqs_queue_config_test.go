@@ -1,7 +1,6 @@
 package remoteconfig
 
 import (
-	"errors"
 	"fmt"
 	"testing"
 
@@ -64,7 +63,7 @@ func (s *SQSQueueConfigSuite) TestValidateErrorRegion() {
 
 	err := validateConfigWithReflection(c)
 	assert.NotNil(s.T(), err)
-	assert.Equal(s.T(), errors.New("Validater Field: Region, failed to validate with error, Region is invalid"), err)
+	assert.EqualError(s.T(), err, "Validater Field: Region, failed to validate with error, Region is invalid")
 }
 
 func (s *SQSQueueConfigSuite) TestValidateErrorAWSAccountID() {
@@ -80,7 +79,23 @@ func (s *SQSQueueConfigSuite) TestValidateErrorAWSAccountID() {
 
 	err := validateConfigWithReflection(c)
 	assert.NotNil(s.T(), err)
-	assert.Equal(s.T(), errors.New("String Field: AWSAccountID, contains an empty string"), err)
+	assert.EqualError(s.T(), err, "String Field: AWSAccountID, contains an empty string")
+}
+
+func (s *SQSQueueConfigSuite) TestValidateErrorAWSAccountIDFormat() {
+	region := VALID_SQS_QUEUE_REGION
+	awsAccountID := "34583330242a"
+	queueName := VALID_SQS_QUEUE_QUEUE_NAME
+
+	c := &SQSQueueConfig{
+		Region:       &region,
+		AWSAccountID: &awsAccountID,
+		QueueName:    &queueName,
+	}
+
+	err := validateConfigWithReflection(c)
+	assert.NotNil(s.T(), err)
+	assert.EqualError(s.T(), err, "Field: AWSAccountID, must match ^[0-9]{12}$")
 }
 
 func (s *SQSQueueConfigSuite) TestValidateErrorQueueName() {
@@ -96,7 +111,7 @@ func (s *SQSQueueConfigSuite) TestValidateErrorQueueName() {
 
 	err := validateConfigWithReflection(c)
 	assert.NotNil(s.T(), err)
-	assert.Equal(s.T(), errors.New("String Field: QueueName, contains an empty string"), err)
+	assert.EqualError(s.T(), err, "String Field: QueueName, contains an empty string")
 }
 
 func (s *SQSQueueConfigSuite) TestGetURLNoEndpoint() {
@@ -0,0 +1,293 @@
+package remoteconfig
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	SQS_API_VERSION                  string        = "2012-11-05"
+	SQS_RELOAD_TRIGGER_WAIT_TIME     time.Duration = 20 * time.Second
+	SQS_RELOAD_TRIGGER_ERROR_BACKOFF time.Duration = 5 * time.Second
+	SQS_RELOAD_TRIGGER_MAX_MESSAGES  int           = 10
+	S3_EVENT_SOURCE                  string        = "aws:s3"
+	S3_EVENT_NAME_OBJECT_CREATED     string        = "ObjectCreated:"
+)
+
+var (
+	ErrSQSReloadTriggerAlreadyStarted = errors.New("SQS reload trigger already started")
+	ErrSQSReloadTriggerQueueNotSet    = errors.New("SQS reload trigger queue not set")
+)
+
+// Reloads a Watcher as soon as an S3 ObjectCreated event notification for its config object arrives on an SQS queue,
+// so polling only acts as a safety net. The queue is long polled and matching events are deleted once received,
+// other messages are left for other consumers unless DeleteUnmatched is set.
+// The exported fields are read by the receiving goroutine, so set them before Start.
+type SQSReloadTrigger struct {
+	// Time a receive waits for messages, up to 20 seconds. Defaults to SQS_RELOAD_TRIGGER_WAIT_TIME.
+	WaitTime time.Duration
+	// Delay after a failed receive before trying again. Defaults to SQS_RELOAD_TRIGGER_ERROR_BACKOFF.
+	ErrorBackoff time.Duration
+	// Called with errors receiving or deleting messages, reload errors are reported by the Watcher.
+	OnError func(err error)
+	// Defaults to http.DefaultClient
+	Client *http.Client
+	// Deletes every message received, not only the events for the config object.
+	// Only for a queue dedicated to the trigger, otherwise unmatched messages return to the queue after its visibility timeout.
+	DeleteUnmatched bool
+
+	watcher  *Watcher
+	queueURL string
+	region   AWSRegion
+	creds    AWSCredentials
+	bucket   string
+	key      string
+
+	// Guards starting and stopping the receiving goroutine
+	mutex  sync.Mutex
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// Creates a trigger for a Watcher of an s3:// config URL, bucket and key are split from it by S3URLToConfig.
+// The client config is optional, when set the queue URL uses its endpoint, i.e. a local fake SQS for testing.
+func NewSQSReloadTrigger(watcher *Watcher, queue *SQSQueueConfig, client *SQSClientConfig, creds AWSCredentials) (*SQSReloadTrigger, error) {
+	s3Config, key, err := S3URLToConfig(watcher.loader.configURL)
+	if err != nil {
+		return nil, err
+	}
+
+	if queue == nil {
+		return nil, ErrSQSReloadTriggerQueueNotSet
+	}
+	if err := validateConfigWithReflection(queue); err != nil {
+		return nil, err
+	}
+	endpoint := ""
+	if client != nil {
+		if err := validateConfigWithReflection(client); err != nil {
+			return nil, err
+		}
+		endpoint = client.GetEndpoint()
+	}
+
+	return &SQSReloadTrigger{
+		WaitTime:     SQS_RELOAD_TRIGGER_WAIT_TIME,
+		ErrorBackoff: SQS_RELOAD_TRIGGER_ERROR_BACKOFF,
+
+		watcher:  watcher,
+		queueURL: queue.GetURL(endpoint),
+		region:   *queue.Region,
+		creds:    creds,
+		bucket:   *s3Config.Bucket,
+		key:      key,
+	}, nil
+}
+
+// Receives messages until the context is done or Stop is called.
+// Stop must be called before starting again, even once the context is done.
+func (t *SQSReloadTrigger) Start(ctx context.Context) error {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if t.done != nil {
+		return ErrSQSReloadTriggerAlreadyStarted
+	}
+
+	ctx, t.cancel = context.WithCancel(ctx)
+	t.done = make(chan struct{})
+	go t.receive(ctx, t.done)
+	return nil
+}
+
+// Stops receiving, returning once the receiving goroutine has exited.
+func (t *SQSReloadTrigger) Stop() {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if t.done == nil {
+		return
+	}
+
+	t.cancel()
+	<-t.done
+	t.cancel = nil
+	t.done = nil
+}
+
+func (t *SQSReloadTrigger) receive(ctx context.Context, done chan struct{}) {
+	defer close(done)
+
+	for ctx.Err() == nil {
+		reload, err := t.receiveOnce(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		if reload {
+			// Reload failures are reported through the Watcher's OnError and Health
+			t.watcher.Reload(ctx)
+		}
+		if err != nil {
+			t.reportError(err)
+			select {
+			case <-ctx.Done():
+			case <-time.After(t.ErrorBackoff):
+			}
+		}
+	}
+}
+
+// Receives a batch of messages and deletes the events for the config object, returning true when there was one.
+func (t *SQSReloadTrigger) receiveOnce(ctx context.Context) (bool, error) {
+	messages, err := t.receiveMessages(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	reload := false
+	for _, message := range messages {
+		matches := t.matchesEvent(message.Body)
+		reload = reload || matches
+		if !matches && !t.DeleteUnmatched {
+			continue
+		}
+		if err := t.deleteMessage(ctx, message.ReceiptHandle); err != nil {
+			t.reportError(err)
+		}
+	}
+	return reload, nil
+}
+
+func (t *SQSReloadTrigger) reportError(err error) {
+	if t.OnError != nil {
+		t.OnError(err)
+	}
+}
+
+type sqsMessage struct {
+	MessageID     string `xml:"MessageId"`
+	ReceiptHandle string `xml:"ReceiptHandle"`
+	Body          string `xml:"Body"`
+}
+
+type sqsReceiveMessageResponse struct {
+	Messages []sqsMessage `xml:"ReceiveMessageResult>Message"`
+}
+
+func (t *SQSReloadTrigger) receiveMessages(ctx context.Context) ([]sqsMessage, error) {
+	params := url.Values{}
+	params.Set("Action", "ReceiveMessage")
+	params.Set("MaxNumberOfMessages", strconv.Itoa(SQS_RELOAD_TRIGGER_MAX_MESSAGES))
+	params.Set("WaitTimeSeconds", strconv.Itoa(int(t.WaitTime/time.Second)))
+
+	resp, err := t.do(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	result := &sqsReceiveMessageResponse{}
+	if err := xml.NewDecoder(resp.Body).Decode(result); err != nil {
+		return nil, err
+	}
+	return result.Messages, nil
+}
+
+func (t *SQSReloadTrigger) deleteMessage(ctx context.Context, receiptHandle string) error {
+	params := url.Values{}
+	params.Set("Action", "DeleteMessage")
+	params.Set("ReceiptHandle", receiptHandle)
+
+	resp, err := t.do(ctx, params)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+// Sends a SigV4 signed SQS query API request to the queue, the response body must be closed by the caller.
+func (t *SQSReloadTrigger) do(ctx context.Context, params url.Values) (*http.Response, error) {
+	params.Set("Version", SQS_API_VERSION)
+	body := params.Encode()
+
+	req, err := http.NewRequest(http.MethodPost, t.queueURL, strings.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if !t.creds.IsAnonymous() {
+		signAWSRequestV4(req, t.creds, t.region, "sqs", hexSHA256([]byte(body)), time.Now())
+	}
+
+	client := t.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, newHTTPStatusError(t.queueURL, resp)
+	}
+	return resp, nil
+}
+
+type s3EventNotification struct {
+	Records []struct {
+		EventSource string `json:"eventSource"`
+		EventName   string `json:"eventName"`
+		S3          struct {
+			Bucket struct {
+				Name string `json:"name"`
+			} `json:"bucket"`
+			Object struct {
+				Key string `json:"key"`
+			} `json:"object"`
+		} `json:"s3"`
+	} `json:"Records"`
+}
+
+type snsNotification struct {
+	Type    string `json:"Type"`
+	Message string `json:"Message"`
+}
+
+// Returns true when a message body is an S3 ObjectCreated event for the config object.
+// Events delivered through an SNS topic are unwrapped from the SNS notification first.
+func (t *SQSReloadTrigger) matchesEvent(body string) bool {
+	sns := &snsNotification{}
+	if err := json.Unmarshal([]byte(body), sns); err == nil && sns.Type == "Notification" {
+		body = sns.Message
+	}
+
+	event := &s3EventNotification{}
+	if err := json.Unmarshal([]byte(body), event); err != nil {
+		return false
+	}
+
+	for _, record := range event.Records {
+		if record.EventSource != S3_EVENT_SOURCE || !strings.HasPrefix(record.EventName, S3_EVENT_NAME_OBJECT_CREATED) {
+			continue
+		}
+		// Keys in event notifications are URL encoded, with spaces as +
+		key, err := url.QueryUnescape(record.S3.Object.Key)
+		if err != nil {
+			continue
+		}
+		if record.S3.Bucket.Name == t.bucket && key == t.key {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,437 @@
+package remoteconfig
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+const (
+	VALID_SQS_RELOAD_TRIGGER_BUCKET = "bucket"
+	VALID_SQS_RELOAD_TRIGGER_KEY    = "configs/my config.json"
+)
+
+// Event notification for the watched object, S3 URL encodes the key with spaces as +
+var validS3EventNotification = `{
+	"Records": [{
+		"eventSource": "aws:s3",
+		"eventName": "ObjectCreated:Put",
+		"s3": {
+			"bucket": {"name": "bucket"},
+			"object": {"key": "configs/my+config.json"}
+		}
+	}]
+}`
+
+// Fake SQS query API endpoint, serving queued message bodies from ReceiveMessage and recording deletes.
+type fakeSQS struct {
+	sync.Mutex
+	messages []string
+	deleted  []string
+	received int
+	// The receive that returned the last messages
+	drainedAt int
+	status    int
+	nextID    int
+}
+
+func (f *fakeSQS) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	if !strings.Contains(r.Header.Get("Authorization"), "/us-east-1/sqs/aws4_request") || r.URL.Path != "/345833302425/testQueue" {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	f.Lock()
+	defer f.Unlock()
+
+	if f.status != 0 {
+		w.WriteHeader(f.status)
+		return
+	}
+
+	switch r.Form.Get("Action") {
+	case "ReceiveMessage":
+		f.received++
+		result := sqsReceiveMessageResponse{}
+		for _, body := range f.messages {
+			f.nextID++
+			result.Messages = append(result.Messages, sqsMessage{
+				MessageID:     fmt.Sprintf("id-%d", f.nextID),
+				ReceiptHandle: fmt.Sprintf("handle-%d", f.nextID),
+				Body:          body,
+			})
+		}
+		if len(f.messages) > 0 {
+			f.drainedAt = f.received
+		}
+		f.messages = nil
+		if len(result.Messages) == 0 {
+			// Stands in for the long poll wait
+			time.Sleep(5 * time.Millisecond)
+		}
+		w.Write([]byte(xml.Header))
+		xml.NewEncoder(w).Encode(struct {
+			XMLName xml.Name `xml:"ReceiveMessageResponse"`
+			sqsReceiveMessageResponse
+		}{sqsReceiveMessageResponse: result})
+	case "DeleteMessage":
+		f.deleted = append(f.deleted, r.Form.Get("ReceiptHandle"))
+		fmt.Fprint(w, "<DeleteMessageResponse></DeleteMessageResponse>")
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+	}
+}
+
+// Queues messages to be returned together by the next receive.
+func (f *fakeSQS) send(bodies ...string) {
+	f.Lock()
+	defer f.Unlock()
+	f.messages = append(f.messages, bodies...)
+}
+
+// Returns true once the messages sent have been received and handled, that's when the trigger receives again.
+func (f *fakeSQS) handled() bool {
+	f.Lock()
+	defer f.Unlock()
+	return len(f.messages) == 0 && f.drainedAt > 0 && f.received > f.drainedAt
+}
+
+func (f *fakeSQS) deletedCount() int {
+	f.Lock()
+	defer f.Unlock()
+	return len(f.deleted)
+}
+
+// Messages that aren't events for the watched object.
+func otherMessages() []string {
+	return []string{
+		strings.Replace(validS3EventNotification, "my+config.json", "other.json", 1),
+		strings.Replace(validS3EventNotification, `"name": "bucket"`, `"name": "other"`, 1),
+		strings.Replace(validS3EventNotification, "ObjectCreated:Put", "ObjectRemoved:Delete", 1),
+		`{"Service": "Amazon S3", "Event": "s3:TestEvent"}`,
+		"Not JSON",
+	}
+}
+
+type SQSReloadTriggerSuite struct {
+	suite.Suite
+	sqs       *fakeSQS
+	sqsServer *httptest.Server
+	s3Server  *httptest.Server
+	creds     AWSCredentials
+
+	mutex     sync.Mutex
+	document  string
+	s3Fetches int
+}
+
+func TestSQSReloadTriggerSuite(t *testing.T) {
+	suite.Run(t, new(SQSReloadTriggerSuite))
+}
+
+func (s *SQSReloadTriggerSuite) SetupTest() {
+	s.creds = AWSCredentials{
+		AccessKeyID:     VALID_SIGV4_ACCESS_KEY_ID,
+		SecretAccessKey: VALID_SIGV4_SECRET_ACCESS_KEY,
+	}
+
+	s.sqs = &fakeSQS{}
+	s.sqsServer = httptest.NewServer(s.sqs)
+
+	s.document = validConfigJSON
+	s.s3Fetches = 0
+	s.s3Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+		s.s3Fetches++
+		fmt.Fprint(w, s.document)
+	}))
+
+	RegisterSource("s3", &S3Source{Region: AWS_REGION_US_EAST_1, Endpoint: s.s3Server.URL, Credentials: &s.creds})
+}
+
+func (s *SQSReloadTriggerSuite) TearDownTest() {
+	RegisterSource("s3", &S3Source{})
+	s.sqsServer.Close()
+	s.s3Server.Close()
+}
+
+func (s *SQSReloadTriggerSuite) serve(document string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.document = document
+}
+
+func (s *SQSReloadTriggerSuite) queueConfigs() (*SQSQueueConfig, *SQSClientConfig) {
+	region := AWS_REGION_US_EAST_1
+	accountID := VALID_SQS_QUEUE_AWS_ACCOUNT_ID
+	queueName := VALID_SQS_QUEUE_QUEUE_NAME
+	endpoint := s.sqsServer.URL
+
+	return &SQSQueueConfig{Region: &region, AWSAccountID: &accountID, QueueName: &queueName},
+		&SQSClientConfig{Region: &region, Endpoint: &endpoint}
+}
+
+// Starts a Watcher that never polls, so reloads only come from the trigger.
+func (s *SQSReloadTriggerSuite) startWatcher() *Watcher {
+	w, err := NewWatcher("s3://bucket/configs/my config.json", &SampleConfig{}, time.Hour)
+	s.Nil(err)
+	s.Nil(w.Start(context.Background()))
+	return w
+}
+
+func (s *SQSReloadTriggerSuite) newTrigger(w *Watcher) *SQSReloadTrigger {
+	queue, client := s.queueConfigs()
+	trigger, err := NewSQSReloadTrigger(w, queue, client, s.creds)
+	s.Nil(err)
+	trigger.ErrorBackoff = time.Millisecond
+	return trigger
+}
+
+func (s *SQSReloadTriggerSuite) TestNewSQSReloadTrigger() {
+	w := s.startWatcher()
+	defer w.Stop()
+
+	trigger := s.newTrigger(w)
+	s.Equal(s.sqsServer.URL+"/345833302425/testQueue", trigger.queueURL)
+	s.Equal(VALID_SQS_RELOAD_TRIGGER_BUCKET, trigger.bucket)
+	s.Equal(VALID_SQS_RELOAD_TRIGGER_KEY, trigger.key)
+	s.Equal(SQS_RELOAD_TRIGGER_WAIT_TIME, trigger.WaitTime)
+}
+
+func (s *SQSReloadTriggerSuite) TestNewSQSReloadTriggerErrors() {
+	queue, client := s.queueConfigs()
+
+	w, err := NewWatcher("https://example.com/config.json", &SampleConfig{}, time.Hour)
+	s.Nil(err)
+	_, err = NewSQSReloadTrigger(w, queue, client, s.creds)
+	s.EqualError(err, "URL does not have the s3:// scheme")
+
+	w, err = NewWatcher("s3://bucket/config.json", &SampleConfig{}, time.Hour)
+	s.Nil(err)
+	queue.QueueName = nil
+	_, err = NewSQSReloadTrigger(w, queue, client, s.creds)
+	s.EqualError(err, "Field: QueueName, not set")
+
+	_, err = NewSQSReloadTrigger(w, nil, client, s.creds)
+	s.Equal(ErrSQSReloadTriggerQueueNotSet, err)
+
+	queue, client = s.queueConfigs()
+	client.Region = nil
+	_, err = NewSQSReloadTrigger(w, queue, client, s.creds)
+	s.EqualError(err, "Field: Region, not set")
+
+	w, err = NewWatcher("s3://bucket", &SampleConfig{}, time.Hour)
+	s.Nil(err)
+	_, err = NewSQSReloadTrigger(w, queue, client, s.creds)
+	s.Equal(ErrS3URLKeyNotSet, err)
+}
+
+func (s *SQSReloadTriggerSuite) TestNewSQSReloadTriggerNoClientConfig() {
+	w, err := NewWatcher("s3://bucket/config.json", &SampleConfig{}, time.Hour)
+	s.Nil(err)
+
+	queue, _ := s.queueConfigs()
+	trigger, err := NewSQSReloadTrigger(w, queue, nil, s.creds)
+	s.Nil(err)
+	s.Equal("https://sqs.us-east-1.amazonaws.com/345833302425/testQueue", trigger.queueURL)
+}
+
+func (s *SQSReloadTriggerSuite) TestReloadOnEvent() {
+	w := s.startWatcher()
+	defer w.Stop()
+	queue, _ := subscribeChanges(w, "str")
+
+	trigger := s.newTrigger(w)
+	s.Nil(trigger.Start(context.Background()))
+	defer trigger.Stop()
+
+	s.serve(strings.Replace(validConfigJSON, `"testStr"`, `"pushed"`, 1))
+	s.sqs.send(validS3EventNotification)
+
+	select {
+	case change := <-queue:
+		s.Equal([]string{"str"}, change.changes)
+		s.Equal("pushed", change.new.Str)
+	case <-time.After(WATCHER_TEST_TIMEOUT):
+		s.Fail("no reload before timeout")
+	}
+	waitFor(s.T(), WATCHER_TEST_TIMEOUT, func() bool { return s.sqs.deletedCount() == 1 })
+}
+
+func (s *SQSReloadTriggerSuite) TestReloadOnEventThroughSNS() {
+	w := s.startWatcher()
+	defer w.Stop()
+
+	trigger := s.newTrigger(w)
+	s.Nil(trigger.Start(context.Background()))
+	defer trigger.Stop()
+
+	s.serve(strings.Replace(validConfigJSON, `"testStr"`, `"pushed"`, 1))
+	envelope, err := json.Marshal(map[string]string{"Type": "Notification", "Message": validS3EventNotification})
+	s.Nil(err)
+	s.sqs.send(string(envelope))
+
+	waitFor(s.T(), WATCHER_TEST_TIMEOUT, func() bool { return currentStr(w) == "pushed" })
+}
+
+func (s *SQSReloadTriggerSuite) TestIgnoresOtherEvents() {
+	w := s.startWatcher()
+	defer w.Stop()
+
+	trigger := s.newTrigger(w)
+	s.Nil(trigger.Start(context.Background()))
+	defer trigger.Stop()
+
+	s.serve(strings.Replace(validConfigJSON, `"testStr"`, `"pushed"`, 1))
+	s.sqs.send(otherMessages()...)
+
+	// The messages are left on the queue for other consumers, without a reload
+	waitFor(s.T(), WATCHER_TEST_TIMEOUT, s.sqs.handled)
+	s.Equal(0, s.sqs.deletedCount())
+	s.mutex.Lock()
+	s.Equal(1, s.s3Fetches)
+	s.mutex.Unlock()
+	s.Equal("testStr", currentStr(w))
+}
+
+func (s *SQSReloadTriggerSuite) TestDeletesOnlyMatchingEvents() {
+	w := s.startWatcher()
+	defer w.Stop()
+
+	trigger := s.newTrigger(w)
+	s.Nil(trigger.Start(context.Background()))
+	defer trigger.Stop()
+
+	s.serve(strings.Replace(validConfigJSON, `"testStr"`, `"pushed"`, 1))
+	s.sqs.send(append(otherMessages(), validS3EventNotification)...)
+
+	waitFor(s.T(), WATCHER_TEST_TIMEOUT, s.sqs.handled)
+	s.Equal("pushed", currentStr(w))
+	s.sqs.Lock()
+	s.Equal([]string{"handle-6"}, s.sqs.deleted)
+	s.sqs.Unlock()
+}
+
+func (s *SQSReloadTriggerSuite) TestDeleteUnmatched() {
+	w := s.startWatcher()
+	defer w.Stop()
+
+	trigger := s.newTrigger(w)
+	trigger.DeleteUnmatched = true
+	s.Nil(trigger.Start(context.Background()))
+	defer trigger.Stop()
+
+	s.sqs.send(otherMessages()...)
+
+	waitFor(s.T(), WATCHER_TEST_TIMEOUT, s.sqs.handled)
+	s.Equal(5, s.sqs.deletedCount())
+	s.Equal("testStr", currentStr(w))
+}
+
+func (s *SQSReloadTriggerSuite) TestInvalidConfigKeepsCurrent() {
+	w := s.startWatcher()
+	defer w.Stop()
+
+	trigger := s.newTrigger(w)
+	s.Nil(trigger.Start(context.Background()))
+	defer trigger.Stop()
+
+	s.serve(`{"str": "invalid"}`)
+	s.sqs.send(validS3EventNotification)
+
+	waitFor(s.T(), WATCHER_TEST_TIMEOUT, func() bool { return w.Health().ConsecutiveFailures == 1 })
+	s.True(errors.Is(w.Health().LastError, ErrValidation))
+	s.Equal("testStr", currentStr(w))
+}
+
+func (s *SQSReloadTriggerSuite) TestReceiveError() {
+	w := s.startWatcher()
+	defer w.Stop()
+
+	s.sqs.Lock()
+	s.sqs.status = http.StatusInternalServerError
+	s.sqs.Unlock()
+
+	errs := make(chan error, 100)
+	trigger := s.newTrigger(w)
+	trigger.OnError = func(err error) {
+		errs <- err
+	}
+	s.Nil(trigger.Start(context.Background()))
+	defer trigger.Stop()
+
+	select {
+	case err := <-errs:
+		s.True(errors.Is(err, ErrHTTPStatus))
+	case <-time.After(WATCHER_TEST_TIMEOUT):
+		s.Fail("no error before timeout")
+	}
+
+	// Receiving carries on once the queue recovers
+	s.sqs.Lock()
+	s.sqs.status = 0
+	s.sqs.Unlock()
+	s.serve(strings.Replace(validConfigJSON, `"testStr"`, `"pushed"`, 1))
+	s.sqs.send(validS3EventNotification)
+	waitFor(s.T(), WATCHER_TEST_TIMEOUT, func() bool { return currentStr(w) == "pushed" })
+}
+
+func (s *SQSReloadTriggerSuite) TestStartStop() {
+	w := s.startWatcher()
+	defer w.Stop()
+
+	trigger := s.newTrigger(w)
+	s.Nil(trigger.Start(context.Background()))
+	s.Equal(ErrSQSReloadTriggerAlreadyStarted, trigger.Start(context.Background()))
+
+	done := trigger.done
+	trigger.Stop()
+	select {
+	case <-done:
+	default:
+		s.Fail("receiving goroutine still running")
+	}
+
+	trigger.Stop()
+	s.Nil(trigger.Start(context.Background()))
+	trigger.Stop()
+}
+
+func (s *SQSReloadTriggerSuite) TestReceiveMessagesRequest() {
+	w := s.startWatcher()
+	defer w.Stop()
+
+	var form map[string][]string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.Nil(r.ParseForm())
+		form = r.Form
+		s.Equal("application/x-www-form-urlencoded", r.Header.Get("Content-Type"))
+		fmt.Fprint(w, "<ReceiveMessageResponse><ReceiveMessageResult></ReceiveMessageResult></ReceiveMessageResponse>")
+	}))
+	defer ts.Close()
+
+	trigger := s.newTrigger(w)
+	trigger.queueURL = ts.URL
+	messages, err := trigger.receiveMessages(context.Background())
+	s.Nil(err)
+	s.Len(messages, 0)
+	s.Equal([]string{"ReceiveMessage"}, form["Action"])
+	s.Equal([]string{"20"}, form["WaitTimeSeconds"])
+	s.Equal([]string{"10"}, form["MaxNumberOfMessages"])
+	s.Equal([]string{SQS_API_VERSION}, form["Version"])
+}
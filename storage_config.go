@@ -1,8 +1,8 @@
 package remoteconfig
 
 type StorageConfig struct {
-	Provider *StorageProvider `json:"provider,omitempty"`
-	Location *StorageLocation `json:"location,omitempty"`
+	Provider *StorageProvider `json:"provider,omitempty" yaml:"provider,omitempty"`
+	Location *StorageLocation `json:"location,omitempty" yaml:"location,omitempty"`
 }
 
 func (s StorageConfig) Validate() error {
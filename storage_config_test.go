@@ -1,7 +1,6 @@
 package remoteconfig
 
 import (
-	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -49,7 +48,7 @@ func (s *StorageConfigSuite) TestValidateConfigWithReflectionErrorProvider() {
 
 	err := validateConfigWithReflection(c)
 	assert.NotNil(s.T(), err)
-	assert.Equal(s.T(), errors.New("Validater Field: StorageConfig, failed to validate with error, Invalid storage provider"), err)
+	assert.EqualError(s.T(), err, "Validater Field: StorageConfig, failed to validate with error, Invalid storage provider")
 }
 
 func (s *StorageConfigSuite) TestValidateConfigWithReflectionErrorLocation() {
@@ -62,7 +61,7 @@ func (s *StorageConfigSuite) TestValidateConfigWithReflectionErrorLocation() {
 
 	err := validateConfigWithReflection(c)
 	assert.NotNil(s.T(), err)
-	assert.Equal(s.T(), errors.New("Validater Field: StorageConfig, failed to validate with error, Region is invalid"), err)
+	assert.EqualError(s.T(), err, "Validater Field: StorageConfig, failed to validate with error, Region is invalid")
 }
 
 func (s *StorageConfigSuite) TestGetProvider() {
@@ -8,6 +8,11 @@ const (
 	STORAGE_PROVIDER_AWS StorageProvider = "aws"
 )
 
+// URL schemes of the Sources for each storage provider
+var storageProviderSchemes = map[StorageProvider]string{
+	STORAGE_PROVIDER_AWS: "s3",
+}
+
 func (s *StorageProvider) UnmarshalText(data []byte) error {
 	sString := string(data[:])
 	*s = (StorageProvider)(sString)
@@ -20,3 +25,8 @@ func (s StorageProvider) Validate() error {
 	}
 	return nil
 }
+
+// Returns the URL scheme the provider's Source is registered under, see GetStorageProviderSource.
+func (s StorageProvider) Scheme() string {
+	return storageProviderSchemes[s]
+}
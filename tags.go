@@ -0,0 +1,159 @@
+package remoteconfig
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+const (
+	tagName                = "remoteconfig"
+	tagJSON                = "json"
+	tagOptional            = "optional"
+	tagDefault             = "default"
+	tagSeparator           = ","
+	tagKeyValueSeparator   = "="
+	tagOneOfSeparator      = "|"
+	tagFieldValueSeparator = ":"
+)
+
+// Parsed remoteconfig struct tag, i.e. `remoteconfig:"optional,default=60,min=1"`
+// A regex or expr rule takes the rest of the tag so it can contain commas, it must be the last part of the tag.
+type fieldTag struct {
+	Optional    bool
+	Default     string
+	HasDefault  bool
+	Constraints []fieldConstraint
+	CrossField  []fieldConstraint
+}
+
+// A constraint rule from a struct tag, i.e. min=1, oneof=a|b|c or required_if=Provider:aws
+type fieldConstraint struct {
+	Rule  string
+	Param string
+
+	// Compiled regex and expr parameters, set by compile when the rule is part of a cached structPlan
+	regex *regexp.Regexp
+	expr  *expr
+
+	// Set when the tag part is misplaced, i.e. optional after a regex rule, reported in place of checking the rule
+	err error
+}
+
+// Compiles a regex or expr parameter ahead of time, an invalid parameter is left for the check to report.
+func (c *fieldConstraint) compile() {
+	if c.err != nil {
+		return
+	}
+	switch c.Rule {
+	case VALIDATION_RULE_REGEX:
+		c.regex, _ = regexp.Compile(c.Param)
+	case VALIDATION_RULE_EXPR:
+		c.expr, _ = parseExpr(c.Param)
+	}
+}
+
+func parseFieldTag(field reflect.StructField) fieldTag {
+	tag := fieldTag{}
+
+	for _, part := range splitTag(field.Tag.Get(tagName)) {
+		key, value := splitTagPart(part)
+		switch key {
+		case tagOptional:
+			tag.Optional = true
+		case tagDefault:
+			tag.Default = value
+			tag.HasDefault = true
+		case VALIDATION_RULE_MIN, VALIDATION_RULE_MAX, VALIDATION_RULE_LEN, VALIDATION_RULE_ONE_OF:
+			tag.Constraints = append(tag.Constraints, fieldConstraint{Rule: key, Param: value})
+		case VALIDATION_RULE_REGEX:
+			tag.Constraints = append(tag.Constraints, fieldConstraint{Rule: key, Param: value, err: checkLastTagPart(key, value)})
+		case VALIDATION_RULE_REQUIRED_WITH, VALIDATION_RULE_EXCLUDED_WITH, VALIDATION_RULE_REQUIRED_IF:
+			tag.CrossField = append(tag.CrossField, fieldConstraint{Rule: key, Param: value})
+		case VALIDATION_RULE_EXPR:
+			tag.CrossField = append(tag.CrossField, fieldConstraint{Rule: key, Param: value, err: checkLastTagPart(key, value)})
+		}
+	}
+
+	return tag
+}
+
+// Tag keys, used to spot a part written after a regex or expr rule, which would be taken as part of the rule
+var tagKeys = map[string]bool{
+	tagOptional:                   true,
+	tagDefault:                    true,
+	VALIDATION_RULE_MIN:           true,
+	VALIDATION_RULE_MAX:           true,
+	VALIDATION_RULE_LEN:           true,
+	VALIDATION_RULE_ONE_OF:        true,
+	VALIDATION_RULE_REGEX:         true,
+	VALIDATION_RULE_REQUIRED_WITH: true,
+	VALIDATION_RULE_EXCLUDED_WITH: true,
+	VALIDATION_RULE_REQUIRED_IF:   true,
+	VALIDATION_RULE_EXPR:          true,
+}
+
+// Returns an error when the value of a regex or expr rule, which takes the rest of the tag, has a tag part after it,
+// i.e. `regex=^[a-z]+$,optional`.
+func checkLastTagPart(rule string, value string) error {
+	parts := strings.Split(value, tagSeparator)
+	for _, part := range parts[1:] {
+		if key, _ := splitTagPart(part); tagKeys[key] {
+			return fmt.Errorf("%s rule must be the last part of the tag, found %s after it", rule, key)
+		}
+	}
+	return nil
+}
+
+// Splits a tag into its parts. A regex or expr part takes the rest of the tag, so the expression can contain commas,
+// which means it has to be the last part, see checkLastTagPart.
+func splitTag(tag string) []string {
+	parts := []string{}
+	for tag != "" {
+		trimmed := strings.TrimSpace(tag)
+		if strings.HasPrefix(trimmed, VALIDATION_RULE_REGEX+tagKeyValueSeparator) || strings.HasPrefix(trimmed, VALIDATION_RULE_EXPR+tagKeyValueSeparator) {
+			return append(parts, tag)
+		}
+
+		i := strings.Index(tag, tagSeparator)
+		if i < 0 {
+			return append(parts, tag)
+		}
+		parts = append(parts, tag[:i])
+		tag = tag[i+1:]
+	}
+	return parts
+}
+
+// Splits a key=value tag part, value is empty for a bare key.
+func splitTagPart(part string) (string, string) {
+	part = strings.TrimSpace(part)
+	if i := strings.Index(part, tagKeyValueSeparator); i >= 0 {
+		return strings.TrimSpace(part[:i]), part[i+1:]
+	}
+	return part, ""
+}
+
+// Returns the name a field is decoded from in JSON, the Go field name unless a json tag renames it.
+func jsonFieldName(field reflect.StructField) string {
+	name := strings.Split(field.Tag.Get(tagJSON), tagSeparator)[0]
+	if name == "" || name == "-" {
+		return field.Name
+	}
+	return name
+}
+
+// Returns true for an embedded struct, or struct pointer, whose fields are promoted into the parent in JSON.
+// An embedded struct with a json name is decoded like a named field.
+func isEmbeddedStruct(field reflect.StructField) bool {
+	if !field.Anonymous || strings.Split(field.Tag.Get(tagJSON), tagSeparator)[0] != "" {
+		return false
+	}
+
+	t := field.Type
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Struct
+}
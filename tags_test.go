@@ -0,0 +1,116 @@
+package remoteconfig
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type TagsSuite struct {
+	suite.Suite
+}
+
+func TestTagsSuite(t *testing.T) {
+	suite.Run(t, new(TagsSuite))
+}
+
+func (s *TagsSuite) parse(tag string) fieldTag {
+	return parseFieldTag(reflect.StructField{Tag: reflect.StructTag(tag)})
+}
+
+func (s *TagsSuite) TestParseFieldTag() {
+	s.Equal(fieldTag{}, s.parse(`json:"a"`))
+	s.Equal(fieldTag{Optional: true}, s.parse(`remoteconfig:"optional"`))
+	s.Equal(fieldTag{Optional: true, Default: "60", HasDefault: true}, s.parse(`remoteconfig:"optional, default=60"`))
+	s.Equal(fieldTag{Default: "", HasDefault: true}, s.parse(`remoteconfig:"default="`))
+	s.Equal(fieldTag{Default: "a=b", HasDefault: true}, s.parse(`remoteconfig:"default=a=b"`))
+}
+
+func (s *TagsSuite) TestSplitTagPart() {
+	key, value := splitTagPart(" optional ")
+	s.Equal("optional", key)
+	s.Equal("", value)
+
+	key, value = splitTagPart("default=1m")
+	s.Equal("default", key)
+	s.Equal("1m", value)
+}
+
+func (s *TagsSuite) TestJSONFieldName() {
+	s.Equal("queue_name", jsonFieldName(reflect.StructField{Name: "QueueName", Tag: `json:"queue_name,omitempty"`}))
+	s.Equal("QueueName", jsonFieldName(reflect.StructField{Name: "QueueName", Tag: `json:",omitempty"`}))
+	s.Equal("QueueName", jsonFieldName(reflect.StructField{Name: "QueueName", Tag: `json:"-"`}))
+	s.Equal("QueueName", jsonFieldName(reflect.StructField{Name: "QueueName"}))
+}
+
+func (s *TagsSuite) TestIsEmbeddedStruct() {
+	t := reflect.TypeOf(struct {
+		EmbeddedConfig
+		*StorageConfig
+		AWSRegion
+		Named               EmbeddedConfig
+		DynamoDBTableConfig `json:"tagged"`
+	}{})
+	s.True(isEmbeddedStruct(t.Field(0)))
+	s.True(isEmbeddedStruct(t.Field(1)))
+	s.False(isEmbeddedStruct(t.Field(2)))
+	s.False(isEmbeddedStruct(t.Field(3)))
+	s.False(isEmbeddedStruct(t.Field(4)))
+}
+
+func (s *TagsSuite) TestParseFieldTagConstraints() {
+	s.Equal(fieldTag{
+		Optional: true,
+		Constraints: []fieldConstraint{
+			{Rule: VALIDATION_RULE_MIN, Param: "1"},
+			{Rule: VALIDATION_RULE_MAX, Param: "3600"},
+			{Rule: VALIDATION_RULE_ONE_OF, Param: "a|b|c"},
+		},
+	}, s.parse(`remoteconfig:"optional,min=1,max=3600,oneof=a|b|c"`))
+
+	// A regex takes the rest of the tag
+	s.Equal(fieldTag{
+		Constraints: []fieldConstraint{
+			{Rule: VALIDATION_RULE_LEN, Param: "12"},
+			{Rule: VALIDATION_RULE_REGEX, Param: "^[0-9]{1,12},optional$"},
+		},
+	}, s.parse(`remoteconfig:"len=12,regex=^[0-9]{1,12},optional$"`))
+}
+
+func (s *TagsSuite) TestParseFieldTagCrossField() {
+	s.Equal(fieldTag{
+		Optional: true,
+		CrossField: []fieldConstraint{
+			{Rule: VALIDATION_RULE_REQUIRED_WITH, Param: "Endpoint"},
+			{Rule: VALIDATION_RULE_EXCLUDED_WITH, Param: "Path"},
+			{Rule: VALIDATION_RULE_REQUIRED_IF, Param: "Provider:aws"},
+		},
+	}, s.parse(`remoteconfig:"optional,required_with=Endpoint,excluded_with=Path,required_if=Provider:aws"`))
+}
+
+func (s *TagsSuite) TestParseFieldTagExpr() {
+	s.Equal(fieldTag{
+		Optional: true,
+		CrossField: []fieldConstraint{
+			{Rule: VALIDATION_RULE_EXPR, Param: "len(self) > 0, self != nil"},
+		},
+	}, s.parse(`remoteconfig:"optional,expr=len(self) > 0, self != nil"`))
+}
+
+func (s *TagsSuite) TestParseFieldTagAfterLastPart() {
+	// A tag part after a regex or expr rule is reported rather than taken as part of the rule
+	tag := s.parse(`remoteconfig:"regex=^[a-z]+$,optional"`)
+	s.False(tag.Optional)
+	s.EqualError(tag.Constraints[0].err, "regex rule must be the last part of the tag, found optional after it")
+
+	tag = s.parse(`remoteconfig:"expr=self > 0, default=1"`)
+	s.EqualError(tag.CrossField[0].err, "expr rule must be the last part of the tag, found default after it")
+
+	type MisplacedTagConfig struct {
+		Name *string `json:"name" remoteconfig:"regex=^[a-z]+$,optional"`
+	}
+	err := validateConfigWithReflection(&MisplacedTagConfig{})
+	s.EqualError(err, "Field: Name, regex rule must be the last part of the tag, found optional after it")
+	s.Equal(VALIDATION_RULE_REGEX, err.(*ValidationError).Rule)
+}
@@ -0,0 +1,161 @@
+package remoteconfig
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	tagYAML       = "yaml"
+	tagYAMLInline = "inline"
+	tagSkip       = "-"
+)
+
+var (
+	jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	yamlUnmarshalerType = reflect.TypeOf((*yaml.Unmarshaler)(nil)).Elem()
+)
+
+// Returns the sorted paths of keys in a document that don't match a field of the config struct, i.e. dynamodb_table.tabel_name.
+// Keys are matched like the decoder for the format matches them, json names for JSON and TOML, yaml names for YAML.
+func findUnknownKeys(data []byte, format Format, configStruct interface{}) ([]string, error) {
+	var doc interface{}
+	var err error
+	switch format {
+	case FORMAT_YAML:
+		err = yaml.Unmarshal(data, &doc)
+	case FORMAT_TOML:
+		tomlDoc := map[string]interface{}{}
+		_, err = toml.Decode(string(data), &tomlDoc)
+		doc = tomlDoc
+	default:
+		err = json.Unmarshal(data, &doc)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	keys := []string{}
+	collectUnknownKeys(doc, reflect.TypeOf(configStruct), "", format == FORMAT_YAML, &keys)
+	sort.Strings(keys)
+	return keys, nil
+}
+
+func collectUnknownKeys(doc interface{}, t reflect.Type, path string, yamlNames bool, keys *[]string) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	// Types that decode themselves, i.e. AWSRegion, aren't walked
+	ptr := reflect.PtrTo(t)
+	if ptr.Implements(jsonUnmarshalerType) || ptr.Implements(yamlUnmarshalerType) || ptr.Implements(textUnmarshalerType) {
+		return
+	}
+
+	d := reflect.ValueOf(doc)
+	switch t.Kind() {
+	case reflect.Struct:
+		if d.Kind() != reflect.Map {
+			return
+		}
+		fields := documentFields(t, yamlNames)
+		for _, key := range d.MapKeys() {
+			name := fmt.Sprint(key.Interface())
+			keyPath := joinFieldPath(path, name)
+
+			fieldType, ok := fields[name]
+			if !ok && !yamlNames {
+				// encoding/json matches keys case insensitively
+				for fieldName, candidate := range fields {
+					if strings.EqualFold(fieldName, name) {
+						fieldType, ok = candidate, true
+						break
+					}
+				}
+			}
+			if !ok {
+				*keys = append(*keys, keyPath)
+				continue
+			}
+			collectUnknownKeys(d.MapIndex(key).Interface(), fieldType, keyPath, yamlNames, keys)
+		}
+
+	case reflect.Slice, reflect.Array:
+		if d.Kind() != reflect.Slice {
+			return
+		}
+		for i := 0; i < d.Len(); i++ {
+			collectUnknownKeys(d.Index(i).Interface(), t.Elem(), fmt.Sprintf("%s[%d]", path, i), yamlNames, keys)
+		}
+
+	case reflect.Map:
+		if d.Kind() != reflect.Map {
+			return
+		}
+		for _, key := range d.MapKeys() {
+			collectUnknownKeys(d.MapIndex(key).Interface(), t.Elem(), joinFieldPath(path, fmt.Sprint(key.Interface())), yamlNames, keys)
+		}
+	}
+}
+
+// Returns the types of a struct's fields by the key they're decoded from, including fields promoted from embedded structs.
+func documentFields(t reflect.Type, yamlNames bool) map[string]reflect.Type {
+	fields := map[string]reflect.Type{}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" && !field.Anonymous {
+			continue
+		}
+
+		name, inline := documentFieldName(field, yamlNames)
+		if name == tagSkip {
+			continue
+		}
+
+		if inline {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			for name, fieldType := range documentFields(embedded, yamlNames) {
+				if _, ok := fields[name]; !ok {
+					fields[name] = fieldType
+				}
+			}
+			continue
+		}
+
+		// Fields of the outer struct win over promoted ones
+		fields[name] = field.Type
+	}
+
+	return fields
+}
+
+// Returns the key a field is decoded from, and whether its fields are promoted into the parent instead.
+func documentFieldName(field reflect.StructField, yamlNames bool) (string, bool) {
+	if !yamlNames {
+		if field.Tag.Get(tagJSON) == tagSkip {
+			return tagSkip, false
+		}
+		return jsonFieldName(field), isEmbeddedStruct(field)
+	}
+
+	parts := strings.Split(field.Tag.Get(tagYAML), tagSeparator)
+	for _, option := range parts[1:] {
+		if option == tagYAMLInline {
+			return "", true
+		}
+	}
+	if parts[0] != "" {
+		return parts[0], false
+	}
+	return strings.ToLower(field.Name), false
+}
@@ -0,0 +1,106 @@
+package remoteconfig
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type UnknownKeysSuite struct {
+	suite.Suite
+}
+
+func TestUnknownKeysSuite(t *testing.T) {
+	suite.Run(t, new(UnknownKeysSuite))
+}
+
+func (s *UnknownKeysSuite) TestFindUnknownKeysValid() {
+	for format, doc := range map[Format]string{
+		FORMAT_JSON: validConfigJSON,
+		FORMAT_YAML: validConfigYAML,
+		FORMAT_TOML: validConfigTOML,
+	} {
+		keys, err := findUnknownKeys([]byte(doc), format, &SampleConfig{})
+		s.Nil(err, string(format))
+		s.Equal([]string{}, keys, string(format))
+	}
+}
+
+func (s *UnknownKeysSuite) TestFindUnknownKeysJSON() {
+	keys, err := findUnknownKeys([]byte(`
+		{
+			"embedded_strng": "abc",
+			"STR": "case insensitive",
+			"dynamodb_table": {"tabel_name": "testTable"},
+			"storage_config_slice": [{"provider": "aws"}, {"provder": "aws"}],
+			"storage_config_map": {"one": {"locaton": "us-west-2"}},
+			"map_str_str": {"any": "value"},
+			"sqs_queue": {"region": "us-east-1"},
+			"extra": {"nested": true}
+		}`), FORMAT_JSON, &SampleConfig{})
+	s.Nil(err)
+	s.Equal([]string{
+		"dynamodb_table.tabel_name",
+		"embedded_strng",
+		"extra",
+		"storage_config_map.one.locaton",
+		"storage_config_slice[1].provder",
+	}, keys)
+}
+
+func (s *UnknownKeysSuite) TestFindUnknownKeysYAML() {
+	keys, err := findUnknownKeys([]byte(`
+embedded_string: abc
+STR: not case insensitive
+dynamodb_table:
+  tabel_name: testTable
+storage_config_slice:
+  - provider: aws
+  - provder: aws
+`), FORMAT_YAML, &SampleConfig{})
+	s.Nil(err)
+	s.Equal([]string{
+		"STR",
+		"dynamodb_table.tabel_name",
+		"storage_config_slice[1].provder",
+	}, keys)
+}
+
+func (s *UnknownKeysSuite) TestFindUnknownKeysTOML() {
+	keys, err := findUnknownKeys([]byte(`
+[dynamodb_table]
+tabel_name = "testTable"
+
+[[storage_config_slice]]
+provider = "aws"
+
+[[storage_config_slice]]
+provder = "aws"
+`), FORMAT_TOML, &SampleConfig{})
+	s.Nil(err)
+	s.Equal([]string{
+		"dynamodb_table.tabel_name",
+		"storage_config_slice[1].provder",
+	}, keys)
+}
+
+func (s *UnknownKeysSuite) TestFindUnknownKeysSkippedFields() {
+	type Config struct {
+		Name    string `json:"name" yaml:"name"`
+		Ignored string `json:"-" yaml:"-"`
+		Default string
+	}
+
+	keys, err := findUnknownKeys([]byte(`{"name": "a", "Ignored": "b", "default": "c"}`), FORMAT_JSON, &Config{})
+	s.Nil(err)
+	s.Equal([]string{"Ignored"}, keys)
+
+	keys, err = findUnknownKeys([]byte("name: a\nignored: b\ndefault: c\n"), FORMAT_YAML, &Config{})
+	s.Nil(err)
+	s.Equal([]string{"ignored"}, keys)
+}
+
+func (s *UnknownKeysSuite) TestFindUnknownKeysInvalidDocument() {
+	_, err := findUnknownKeys([]byte("Not JSON"), FORMAT_JSON, &SampleConfig{})
+	s.NotNil(err)
+}
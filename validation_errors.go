@@ -0,0 +1,101 @@
+package remoteconfig
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Every problem found when validating a config with WithAllValidationErrors.
+// errors.Is and errors.As match against any of the individual errors.
+type ValidationErrors []error
+
+func (e ValidationErrors) Error() string {
+	if len(e) == 1 {
+		return e[0].Error()
+	}
+
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return fmt.Sprintf("Config failed to validate with %d errors, %s", len(e), strings.Join(msgs, "; "))
+}
+
+func (e ValidationErrors) Is(target error) bool {
+	for _, err := range e {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+func (e ValidationErrors) As(target interface{}) bool {
+	for _, err := range e {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// Validation rules reported by ValidationError.
+const (
+	VALIDATION_RULE_REQUIRED  = "required"
+	VALIDATION_RULE_NOT_EMPTY = "notempty"
+	VALIDATION_RULE_VALIDATE  = "validate"
+	VALIDATION_RULE_MIN       = "min"
+	VALIDATION_RULE_MAX       = "max"
+	VALIDATION_RULE_LEN       = "len"
+	VALIDATION_RULE_ONE_OF    = "oneof"
+	VALIDATION_RULE_REGEX     = "regex"
+
+	VALIDATION_RULE_REQUIRED_WITH = "required_with"
+	VALIDATION_RULE_EXCLUDED_WITH = "excluded_with"
+	VALIDATION_RULE_REQUIRED_IF   = "required_if"
+
+	VALIDATION_RULE_EXPR = "expr"
+)
+
+// A single problem found when validating a config.
+// Matches ErrValidation with errors.Is, and unwraps to the error from a Validate method or constraint rule.
+type ValidationError struct {
+	// Go path of the field, i.e. StorageConfigMap["one"].Provider
+	Path string
+	// Path of the field using its json names, i.e. storage_config_map.one.provider
+	JSONPath string
+	// Rule that failed, i.e. VALIDATION_RULE_REQUIRED
+	Rule string
+	// Parameter of the rule from the struct tag, i.e. 3600 for max=3600
+	Param string
+	// Value of the field that failed to validate
+	Value interface{}
+	// Error returned by the field's Validate method, or describing why a constraint rule failed.
+	// Nil for the required and notempty rules.
+	Err error
+}
+
+func (e *ValidationError) Error() string {
+	switch e.Rule {
+	case VALIDATION_RULE_REQUIRED:
+		return fmt.Sprintf("Field: %s, not set", e.Path)
+	case VALIDATION_RULE_NOT_EMPTY:
+		if reflect.ValueOf(e.Value).Kind() == reflect.Slice {
+			return fmt.Sprintf("Slice Field: %s, is empty", e.Path)
+		}
+		return fmt.Sprintf("String Field: %s, contains an empty string", e.Path)
+	case VALIDATION_RULE_VALIDATE:
+		return fmt.Sprintf("Validater Field: %s, failed to validate with error, %s", e.Path, e.Err)
+	}
+	return fmt.Sprintf("Field: %s, %s", e.Path, e.Err)
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+func (e *ValidationError) Is(target error) bool {
+	return target == ErrValidation
+}
@@ -0,0 +1,82 @@
+package remoteconfig
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ValidationErrorsSuite struct {
+	suite.Suite
+}
+
+func TestValidationErrorsSuite(t *testing.T) {
+	suite.Run(t, new(ValidationErrorsSuite))
+}
+
+func (s *ValidationErrorsSuite) TestError() {
+	s.EqualError(ValidationErrors{errors.New("Field: A, not set")}, "Field: A, not set")
+	s.EqualError(ValidationErrors{errors.New("Field: A, not set"), errors.New("Field: B, not set")},
+		"Config failed to validate with 2 errors, Field: A, not set; Field: B, not set")
+}
+
+func (s *ValidationErrorsSuite) TestIs() {
+	err := ValidationErrors{errors.New("Field: A, not set"), ErrAWSRegionInvalid}
+	s.True(errors.Is(err, ErrAWSRegionInvalid))
+	s.False(errors.Is(err, ErrAWSRegionEmptyString))
+}
+
+func (s *ValidationErrorsSuite) TestAs() {
+	var err error = ValidationErrors{errors.New("Field: A, not set"), &HTTPStatusError{StatusCode: 404}}
+
+	var statusErr *HTTPStatusError
+	s.True(errors.As(err, &statusErr))
+	s.Equal(404, statusErr.StatusCode)
+
+	var timeoutErr *TimeoutError
+	s.False(errors.As(err, &timeoutErr))
+}
+
+func (s *ValidationErrorsSuite) TestValidationErrorMessages() {
+	str := ""
+	s.EqualError(&ValidationError{Path: "SQSQueue.Region", Rule: VALIDATION_RULE_REQUIRED}, "Field: SQSQueue.Region, not set")
+	s.EqualError(&ValidationError{Path: "Str", Rule: VALIDATION_RULE_NOT_EMPTY, Value: ""}, "String Field: Str, contains an empty string")
+	s.EqualError(&ValidationError{Path: "StrPointer", Rule: VALIDATION_RULE_NOT_EMPTY, Value: &str}, "String Field: StrPointer, contains an empty string")
+	s.EqualError(&ValidationError{Path: "Slice", Rule: VALIDATION_RULE_NOT_EMPTY, Value: []string{}}, "Slice Field: Slice, is empty")
+	s.EqualError(&ValidationError{Path: "Region", Rule: VALIDATION_RULE_VALIDATE, Err: ErrAWSRegionInvalid}, "Validater Field: Region, failed to validate with error, Region is invalid")
+}
+
+func (s *ValidationErrorsSuite) TestValidationErrorUnwrap() {
+	var err error = &ValidationError{Path: "Region", Rule: VALIDATION_RULE_VALIDATE, Err: ErrAWSRegionInvalid}
+	s.True(errors.Is(err, ErrAWSRegionInvalid))
+	s.True(errors.Is(ValidationErrors{err}, ErrAWSRegionInvalid))
+
+	var validationErr *ValidationError
+	s.True(errors.As(ValidationErrors{err}, &validationErr))
+	s.Equal("Region", validationErr.Path)
+}
+
+func (s *ValidationErrorsSuite) TestIsThroughCollectedErrors() {
+	// Errors from Validate methods are wrapped, so they're found through the collected errors of a walk
+	name := "a"
+	c := &ValueConfig{
+		Sub:      ValueSubConfig{Name: &name, Region: AWSRegion("invalid")},
+		SubSlice: []ValueSubConfig{{Name: &name, Region: AWSRegion("invalid")}},
+	}
+	err := validateAllConfigWithReflection(c)
+
+	var errs ValidationErrors
+	s.True(errors.As(err, &errs))
+	s.True(len(errs) > 2)
+	s.True(errors.Is(err, ErrAWSRegionInvalid))
+
+	found := []string{}
+	for _, entry := range errs {
+		var validationErr *ValidationError
+		if errors.Is(entry, ErrAWSRegionInvalid) && errors.As(entry, &validationErr) {
+			found = append(found, validationErr.Path)
+		}
+	}
+	s.Equal([]string{"Sub.Region", "SubSlice[0].Region"}, found)
+}
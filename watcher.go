@@ -0,0 +1,298 @@
+package remoteconfig
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+var (
+	ErrWatcherConfigType     = errors.New("Watcher config must be a pointer to a struct")
+	ErrWatcherInterval       = errors.New("Watcher interval must be greater than 0")
+	ErrWatcherAlreadyStarted = errors.New("Watcher already started")
+)
+
+// Called when a new valid config replaces the old one, with the paths of the changed fields within the subscribed subtree.
+// The configs are shared with other readers and must not be modified.
+type ChangeFunc func(old interface{}, new interface{}, changes []string)
+
+// Health of a Watcher's reloads.
+type WatcherHealth struct {
+	// When a config was last loaded and found valid, zero before the first
+	LastSuccess time.Time
+	// Error from the last failed reload, kept after later reloads succeed
+	LastError error
+	// When the last failed reload happened, zero before the first
+	LastFailure time.Time
+	// Reloads that failed since the last one that succeeded
+	ConsecutiveFailures int
+}
+
+type subscription struct {
+	path string
+	fn   ChangeFunc
+}
+
+// Reloads a config from a URL on an interval.
+// Each reload decodes into a fresh struct and is only swapped in once it's valid, readers get the latest with Current.
+type Watcher struct {
+	loader     *Loader
+	configType reflect.Type
+	interval   time.Duration
+
+	// Holds the latest valid config, a pointer of the same type as the one passed to NewWatcher
+	current atomic.Value
+
+	// Serializes reloads, so configs are swapped in the order they were loaded
+	reloadMutex sync.Mutex
+
+	subscriptionsMutex sync.Mutex
+	subscriptions      map[int]*subscription
+	errorHandlers      map[int]func(err error)
+	nextSubscriptionID int
+
+	healthMutex sync.RWMutex
+	health      WatcherHealth
+
+	// Guards starting and stopping the polling goroutine
+	mutex  sync.Mutex
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// Creates a Watcher for the config at a URL, loaded with a Loader and the load options.
+// Reloads of a document that hasn't changed are skipped with conditional requests where the source supports them.
+// The config struct is a pointer to the type to decode into, i.e. &SampleConfig{}, only its type is used.
+func NewWatcher(configURL string, configStruct interface{}, interval time.Duration, opts ...LoadOption) (*Watcher, error) {
+	t := reflect.TypeOf(configStruct)
+	if t == nil || t.Kind() != reflect.Ptr || t.Elem().Kind() != reflect.Struct {
+		return nil, ErrWatcherConfigType
+	}
+	if interval <= 0 {
+		return nil, ErrWatcherInterval
+	}
+
+	return &Watcher{
+		loader:     NewLoader(configURL, opts...),
+		configType: t.Elem(),
+		interval:   interval,
+
+		subscriptions: map[int]*subscription{},
+		errorHandlers: map[int]func(err error){},
+	}, nil
+}
+
+// Returns the latest valid config, or nil before the first successful load.
+// The config is shared with other readers and must not be modified.
+func (w *Watcher) Current() interface{} {
+	return w.current.Load()
+}
+
+// Loads the config, then reloads it on the interval until the context is done or Stop is called.
+// When the first load fails its error is returned and the Watcher isn't started.
+// Stop must be called before starting again, even once the context is done.
+func (w *Watcher) Start(ctx context.Context) error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if w.done != nil {
+		return ErrWatcherAlreadyStarted
+	}
+
+	if err := w.Reload(ctx); err != nil {
+		return err
+	}
+
+	ctx, w.cancel = context.WithCancel(ctx)
+	w.done = make(chan struct{})
+	go w.poll(ctx, w.done)
+	return nil
+}
+
+// Stops reloading, returning once the polling goroutine has exited. The Watcher can be started again.
+func (w *Watcher) Stop() {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if w.done == nil {
+		return
+	}
+
+	w.cancel()
+	<-w.done
+	w.cancel = nil
+	w.done = nil
+}
+
+// Loads the config now, swapping it in when it has changed and is valid.
+// A config that fails to load, decode or validate leaves the last valid one in place, the failure is returned,
+// passed to the OnError functions and counted in the Health. A load cancelled through the context isn't counted.
+func (w *Watcher) Reload(ctx context.Context) error {
+	w.reloadMutex.Lock()
+	defer w.reloadMutex.Unlock()
+
+	configStruct := reflect.New(w.configType).Interface()
+	result, err := w.loader.Load(ctx, configStruct)
+	if err != nil {
+		if ctx.Err() != context.Canceled {
+			w.recordFailure(err)
+		}
+		return err
+	}
+	if result.Unchanged {
+		w.recordSuccess()
+		return nil
+	}
+
+	old := w.current.Load()
+	w.current.Store(configStruct)
+	w.recordSuccess()
+
+	if old != nil {
+		w.notify(old, configStruct)
+	}
+	return nil
+}
+
+// Returns the metadata of the document the current config was loaded from, or nil before the first successful load.
+func (w *Watcher) Metadata() *SourceMetadata {
+	return w.loader.Metadata()
+}
+
+// Returns the health of the reloads.
+func (w *Watcher) Health() WatcherHealth {
+	w.healthMutex.RLock()
+	defer w.healthMutex.RUnlock()
+	return w.health
+}
+
+func (w *Watcher) recordSuccess() {
+	w.healthMutex.Lock()
+	defer w.healthMutex.Unlock()
+	w.health.LastSuccess = time.Now()
+	w.health.ConsecutiveFailures = 0
+}
+
+func (w *Watcher) recordFailure(err error) {
+	w.healthMutex.Lock()
+	w.health.LastError = err
+	w.health.LastFailure = time.Now()
+	w.health.ConsecutiveFailures++
+	w.healthMutex.Unlock()
+
+	for _, fn := range w.getErrorHandlers() {
+		fn(err)
+	}
+}
+
+// Registers a function called with the error when a reload fails, use errors.Is to tell i.e. ErrValidation from ErrNotFound.
+// Functions are called in the order they were registered, on the goroutine doing the reload, and mustn't call Reload or Stop.
+// Returns a function that unregisters.
+func (w *Watcher) OnError(fn func(err error)) func() {
+	w.subscriptionsMutex.Lock()
+	defer w.subscriptionsMutex.Unlock()
+
+	id := w.nextSubscriptionID
+	w.nextSubscriptionID++
+	w.errorHandlers[id] = fn
+
+	return func() {
+		w.subscriptionsMutex.Lock()
+		defer w.subscriptionsMutex.Unlock()
+		delete(w.errorHandlers, id)
+	}
+}
+
+func (w *Watcher) getErrorHandlers() []func(err error) {
+	w.subscriptionsMutex.Lock()
+	defer w.subscriptionsMutex.Unlock()
+
+	ids := make([]int, 0, len(w.errorHandlers))
+	for id := range w.errorHandlers {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	handlers := make([]func(err error), len(ids))
+	for i, id := range ids {
+		handlers[i] = w.errorHandlers[id]
+	}
+	return handlers
+}
+
+// Registers a function called when a reload changes the subtree of the config at the path, i.e. sqs_queue.
+// The path uses json names like ValidationError.JSONPath, an empty path subscribes to the whole config.
+// Functions are called in the order they subscribed, on the goroutine doing the reload, and mustn't call Reload or Stop.
+// Returns a function that unsubscribes.
+func (w *Watcher) Subscribe(path string, fn ChangeFunc) func() {
+	w.subscriptionsMutex.Lock()
+	defer w.subscriptionsMutex.Unlock()
+
+	id := w.nextSubscriptionID
+	w.nextSubscriptionID++
+	w.subscriptions[id] = &subscription{path: path, fn: fn}
+
+	return func() {
+		w.subscriptionsMutex.Lock()
+		defer w.subscriptionsMutex.Unlock()
+		delete(w.subscriptions, id)
+	}
+}
+
+// Calls the subscriptions affected by the changes between two configs.
+func (w *Watcher) notify(old interface{}, new interface{}) {
+	changes := diffConfig(old, new)
+	if len(changes) == 0 {
+		return
+	}
+
+	for _, sub := range w.getSubscriptions() {
+		affected := []string{}
+		for _, change := range changes {
+			if pathAffected(change, sub.path) {
+				affected = append(affected, change)
+			}
+		}
+		if len(affected) > 0 {
+			sub.fn(old, new, affected)
+		}
+	}
+}
+
+func (w *Watcher) getSubscriptions() []*subscription {
+	w.subscriptionsMutex.Lock()
+	defer w.subscriptionsMutex.Unlock()
+
+	ids := make([]int, 0, len(w.subscriptions))
+	for id := range w.subscriptions {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	subscriptions := make([]*subscription, len(ids))
+	for i, id := range ids {
+		subscriptions[i] = w.subscriptions[id]
+	}
+	return subscriptions
+}
+
+func (w *Watcher) poll(ctx context.Context, done chan struct{}) {
+	defer close(done)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			// A failed reload keeps serving the last valid config, the error is reported through OnError and Health
+			w.Reload(ctx)
+		}
+	}
+}
@@ -0,0 +1,518 @@
+package remoteconfig
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+const (
+	WATCHER_TEST_INTERVAL = 10 * time.Millisecond
+	WATCHER_TEST_TIMEOUT  = 2 * time.Second
+)
+
+type WatcherSuite struct {
+	suite.Suite
+	server *httptest.Server
+
+	mutex    sync.Mutex
+	document string
+	status   int
+	requests int
+}
+
+func TestWatcherSuite(t *testing.T) {
+	suite.Run(t, new(WatcherSuite))
+}
+
+func (s *WatcherSuite) SetupTest() {
+	s.document = validConfigJSON
+	s.status = http.StatusOK
+	s.requests = 0
+	s.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+		s.requests++
+		w.WriteHeader(s.status)
+		fmt.Fprint(w, s.document)
+	}))
+}
+
+func (s *WatcherSuite) TearDownTest() {
+	s.server.Close()
+}
+
+func (s *WatcherSuite) serve(document string, status int) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.document = document
+	s.status = status
+}
+
+func (s *WatcherSuite) requestCount() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.requests
+}
+
+// Waits for the condition to hold, failing the test if it doesn't before the timeout.
+func waitFor(t *testing.T, timeout time.Duration, condition func() bool) {
+	deadline := time.Now().Add(timeout)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatalf("condition not met before %s", timeout)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func (s *WatcherSuite) newWatcher() *Watcher {
+	w, err := NewWatcher(s.server.URL+"/config.json", &SampleConfig{}, WATCHER_TEST_INTERVAL)
+	s.Nil(err)
+	return w
+}
+
+func currentStr(w *Watcher) string {
+	c, ok := w.Current().(*SampleConfig)
+	if !ok {
+		return ""
+	}
+	return c.Str
+}
+
+func (s *WatcherSuite) TestNewWatcherErrors() {
+	_, err := NewWatcher(s.server.URL, SampleConfig{}, WATCHER_TEST_INTERVAL)
+	s.Equal(ErrWatcherConfigType, err)
+
+	_, err = NewWatcher(s.server.URL, nil, WATCHER_TEST_INTERVAL)
+	s.Equal(ErrWatcherConfigType, err)
+
+	str := "abc"
+	_, err = NewWatcher(s.server.URL, &str, WATCHER_TEST_INTERVAL)
+	s.Equal(ErrWatcherConfigType, err)
+
+	_, err = NewWatcher(s.server.URL, &SampleConfig{}, 0)
+	s.Equal(ErrWatcherInterval, err)
+}
+
+func (s *WatcherSuite) TestStartLoadsConfig() {
+	w := s.newWatcher()
+	s.Nil(w.Current())
+
+	s.Nil(w.Start(context.Background()))
+	defer w.Stop()
+
+	c, ok := w.Current().(*SampleConfig)
+	s.True(ok)
+	s.Equal("testStr", c.Str)
+}
+
+func (s *WatcherSuite) TestReloadsOnInterval() {
+	w := s.newWatcher()
+	s.Nil(w.Start(context.Background()))
+	defer w.Stop()
+
+	first := w.Current()
+	s.serve(strings.Replace(validConfigJSON, `"testStr"`, `"reloaded"`, 1), http.StatusOK)
+
+	waitFor(s.T(), WATCHER_TEST_TIMEOUT, func() bool { return currentStr(w) == "reloaded" })
+
+	// Each reload decodes into a fresh struct, so old snapshots are left alone
+	s.Equal("testStr", first.(*SampleConfig).Str)
+}
+
+func (s *WatcherSuite) TestReloadInvalidKeepsCurrent() {
+	w := s.newWatcher()
+	s.Nil(w.Start(context.Background()))
+	defer w.Stop()
+
+	current := w.Current()
+	s.serve(`{"str": "invalid"}`, http.StatusOK)
+
+	requests := s.requestCount()
+	waitFor(s.T(), WATCHER_TEST_TIMEOUT, func() bool { return s.requestCount() > requests+2 })
+	s.True(current == w.Current())
+
+	s.serve("Not JSON", http.StatusOK)
+	requests = s.requestCount()
+	waitFor(s.T(), WATCHER_TEST_TIMEOUT, func() bool { return s.requestCount() > requests+2 })
+	s.True(current == w.Current())
+}
+
+func (s *WatcherSuite) TestReload() {
+	w, err := NewWatcher(s.server.URL+"/config.json", &SampleConfig{}, time.Hour)
+	s.Nil(err)
+	s.Nil(w.Start(context.Background()))
+	defer w.Stop()
+
+	s.serve(strings.Replace(validConfigJSON, `"testStr"`, `"reloaded"`, 1), http.StatusOK)
+	s.Nil(w.Reload(context.Background()))
+	s.Equal("reloaded", currentStr(w))
+
+	s.serve("", http.StatusNotFound)
+	err = w.Reload(context.Background())
+	s.True(errors.Is(err, ErrNotFound))
+	s.Equal("reloaded", currentStr(w))
+}
+
+func (s *WatcherSuite) TestStartErrorFirstLoad() {
+	s.serve(`{"str": "invalid"}`, http.StatusOK)
+
+	w := s.newWatcher()
+	err := w.Start(context.Background())
+	s.True(errors.Is(err, ErrValidation))
+	s.Nil(w.Current())
+
+	// Not started, so there's nothing to stop and it can be started once the config is fixed
+	w.Stop()
+	s.serve(validConfigJSON, http.StatusOK)
+	s.Nil(w.Start(context.Background()))
+	w.Stop()
+}
+
+func (s *WatcherSuite) TestStartErrorAlreadyStarted() {
+	w := s.newWatcher()
+	s.Nil(w.Start(context.Background()))
+	defer w.Stop()
+
+	s.Equal(ErrWatcherAlreadyStarted, w.Start(context.Background()))
+}
+
+func (s *WatcherSuite) TestStop() {
+	w := s.newWatcher()
+	s.Nil(w.Start(context.Background()))
+
+	done := w.done
+	w.Stop()
+
+	// The polling goroutine has exited by the time Stop returns
+	select {
+	case <-done:
+	default:
+		s.Fail("polling goroutine still running")
+	}
+
+	requests := s.requestCount()
+	time.Sleep(5 * WATCHER_TEST_INTERVAL)
+	s.Equal(requests, s.requestCount())
+
+	// Stopping again does nothing, and the Watcher can be restarted
+	w.Stop()
+	s.Nil(w.Start(context.Background()))
+	w.Stop()
+}
+
+func (s *WatcherSuite) TestStopOnContextDone() {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	w := s.newWatcher()
+	s.Nil(w.Start(ctx))
+	done := w.done
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(WATCHER_TEST_TIMEOUT):
+		s.Fail("polling goroutine didn't exit when the context was done")
+	}
+
+	s.Equal(ErrWatcherAlreadyStarted, w.Start(context.Background()))
+	w.Stop()
+	s.Nil(w.Start(context.Background()))
+	w.Stop()
+}
+
+func (s *WatcherSuite) TestCurrentConcurrentReads() {
+	w := s.newWatcher()
+	s.Nil(w.Start(context.Background()))
+	defer w.Stop()
+
+	s.serve(strings.Replace(validConfigJSON, `"testStr"`, `"reloaded"`, 1), http.StatusOK)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				if c, ok := w.Current().(*SampleConfig); !ok || c.Str == "" {
+					s.Fail("Current returned an incomplete config")
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+type watcherChange struct {
+	old     *SampleConfig
+	new     *SampleConfig
+	changes []string
+}
+
+// Subscribes to the path, sending each change on the returned channel.
+func subscribeChanges(w *Watcher, path string) (chan watcherChange, func()) {
+	ch := make(chan watcherChange, 10)
+	unsubscribe := w.Subscribe(path, func(old interface{}, new interface{}, changes []string) {
+		ch <- watcherChange{old: old.(*SampleConfig), new: new.(*SampleConfig), changes: changes}
+	})
+	return ch, unsubscribe
+}
+
+func (s *WatcherSuite) TestSubscribe() {
+	w, err := NewWatcher(s.server.URL+"/config.json", &SampleConfig{}, time.Hour)
+	s.Nil(err)
+
+	all, _ := subscribeChanges(w, "")
+	queue, _ := subscribeChanges(w, "sqs_queue")
+	client, _ := subscribeChanges(w, "sqs_client.region")
+
+	// The first load has nothing to compare against
+	s.Nil(w.Start(context.Background()))
+	defer w.Stop()
+	s.Len(all, 0)
+
+	doc := strings.Replace(validConfigJSON, `"testQueue"`, `"otherQueue"`, 1)
+	doc = strings.Replace(doc, `"testStr"`, `"otherStr"`, 1)
+	s.serve(doc, http.StatusOK)
+	s.Nil(w.Reload(context.Background()))
+
+	change := <-all
+	s.Equal([]string{"sqs_queue.queue_name", "str"}, change.changes)
+	s.Equal("testStr", change.old.Str)
+	s.Equal("otherStr", change.new.Str)
+	s.True(change.new == w.Current())
+
+	change = <-queue
+	s.Equal([]string{"sqs_queue.queue_name"}, change.changes)
+	s.Equal("testQueue", *change.old.SQSQueue.QueueName)
+	s.Equal("otherQueue", *change.new.SQSQueue.QueueName)
+
+	s.Len(client, 0)
+}
+
+func (s *WatcherSuite) TestSubscribeUnchanged() {
+	w, err := NewWatcher(s.server.URL+"/config.json", &SampleConfig{}, time.Hour)
+	s.Nil(err)
+	all, _ := subscribeChanges(w, "")
+
+	s.Nil(w.Start(context.Background()))
+	defer w.Stop()
+
+	// The config is still swapped in, but without changes nobody is told
+	current := w.Current()
+	s.Nil(w.Reload(context.Background()))
+	s.False(current == w.Current())
+	s.Len(all, 0)
+}
+
+func (s *WatcherSuite) TestSubscribeInvalidConfig() {
+	w, err := NewWatcher(s.server.URL+"/config.json", &SampleConfig{}, time.Hour)
+	s.Nil(err)
+	all, _ := subscribeChanges(w, "")
+
+	s.Nil(w.Start(context.Background()))
+	defer w.Stop()
+
+	s.serve(`{"str": "invalid"}`, http.StatusOK)
+	s.NotNil(w.Reload(context.Background()))
+	s.Len(all, 0)
+}
+
+func (s *WatcherSuite) TestUnsubscribe() {
+	w, err := NewWatcher(s.server.URL+"/config.json", &SampleConfig{}, time.Hour)
+	s.Nil(err)
+	all, unsubscribe := subscribeChanges(w, "")
+	other, _ := subscribeChanges(w, "")
+
+	s.Nil(w.Start(context.Background()))
+	defer w.Stop()
+
+	unsubscribe()
+	unsubscribe()
+
+	s.serve(strings.Replace(validConfigJSON, `"testStr"`, `"otherStr"`, 1), http.StatusOK)
+	s.Nil(w.Reload(context.Background()))
+	s.Len(all, 0)
+	s.Len(other, 1)
+}
+
+func (s *WatcherSuite) TestSubscribeOrder() {
+	w, err := NewWatcher(s.server.URL+"/config.json", &SampleConfig{}, time.Hour)
+	s.Nil(err)
+
+	calls := []int{}
+	for i := 0; i < 5; i++ {
+		i := i
+		w.Subscribe("", func(old interface{}, new interface{}, changes []string) {
+			calls = append(calls, i)
+		})
+	}
+
+	s.Nil(w.Start(context.Background()))
+	defer w.Stop()
+
+	s.serve(strings.Replace(validConfigJSON, `"testStr"`, `"otherStr"`, 1), http.StatusOK)
+	s.Nil(w.Reload(context.Background()))
+	s.Equal([]int{0, 1, 2, 3, 4}, calls)
+}
+
+func (s *WatcherSuite) TestSubscribePolling() {
+	w := s.newWatcher()
+	queue, _ := subscribeChanges(w, "sqs_queue")
+
+	s.Nil(w.Start(context.Background()))
+	defer w.Stop()
+
+	s.serve(strings.Replace(validConfigJSON, `"testQueue"`, `"otherQueue"`, 1), http.StatusOK)
+	select {
+	case change := <-queue:
+		s.Equal([]string{"sqs_queue.queue_name"}, change.changes)
+	case <-time.After(WATCHER_TEST_TIMEOUT):
+		s.Fail("no change before timeout")
+	}
+}
+
+func (s *WatcherSuite) TestHealth() {
+	w, err := NewWatcher(s.server.URL+"/config.json", &SampleConfig{}, time.Hour)
+	s.Nil(err)
+	s.Equal(WatcherHealth{}, w.Health())
+
+	before := time.Now()
+	s.Nil(w.Start(context.Background()))
+	defer w.Stop()
+
+	health := w.Health()
+	s.False(health.LastSuccess.Before(before))
+	s.Nil(health.LastError)
+	s.True(health.LastFailure.IsZero())
+	s.Equal(0, health.ConsecutiveFailures)
+
+	current := w.Current()
+	s.serve(`{"str": "invalid"}`, http.StatusOK)
+	s.NotNil(w.Reload(context.Background()))
+	s.serve("Not JSON", http.StatusOK)
+	s.NotNil(w.Reload(context.Background()))
+
+	// The last valid config is still served
+	s.True(current == w.Current())
+
+	health = w.Health()
+	s.True(errors.Is(health.LastError, ErrDecode))
+	s.False(health.LastFailure.Before(health.LastSuccess))
+	s.Equal(2, health.ConsecutiveFailures)
+
+	s.serve(validConfigJSON, http.StatusOK)
+	s.Nil(w.Reload(context.Background()))
+
+	health = w.Health()
+	s.False(health.LastSuccess.Before(health.LastFailure))
+	s.True(errors.Is(health.LastError, ErrDecode))
+	s.Equal(0, health.ConsecutiveFailures)
+}
+
+func (s *WatcherSuite) TestHealthStartErrorFirstLoad() {
+	s.serve("", http.StatusNotFound)
+
+	w := s.newWatcher()
+	s.NotNil(w.Start(context.Background()))
+
+	health := w.Health()
+	s.True(health.LastSuccess.IsZero())
+	s.True(errors.Is(health.LastError, ErrNotFound))
+	s.Equal(1, health.ConsecutiveFailures)
+}
+
+func (s *WatcherSuite) TestOnError() {
+	w := s.newWatcher()
+
+	errs := make(chan error, 100)
+	w.OnError(func(err error) {
+		errs <- err
+	})
+	s.Nil(w.Start(context.Background()))
+	defer w.Stop()
+
+	s.serve(`{"str": "invalid"}`, http.StatusOK)
+	select {
+	case err := <-errs:
+		s.True(errors.Is(err, ErrValidation))
+	case <-time.After(WATCHER_TEST_TIMEOUT):
+		s.Fail("no error before timeout")
+	}
+	s.Equal("testStr", currentStr(w))
+}
+
+func (s *WatcherSuite) TestOnErrorUnregister() {
+	w, err := NewWatcher(s.server.URL+"/config.json", &SampleConfig{}, time.Hour)
+	s.Nil(err)
+
+	calls := []string{}
+	unregister := w.OnError(func(err error) { calls = append(calls, "first") })
+	w.OnError(func(err error) { calls = append(calls, "second") })
+	w.OnError(func(err error) { calls = append(calls, "third") })
+
+	s.serve("Not JSON", http.StatusOK)
+	s.NotNil(w.Reload(context.Background()))
+	s.Equal([]string{"first", "second", "third"}, calls)
+
+	unregister()
+	calls = []string{}
+	s.NotNil(w.Reload(context.Background()))
+	s.Equal([]string{"second", "third"}, calls)
+}
+
+func (s *WatcherSuite) TestReloadCanceledNotCounted() {
+	w, err := NewWatcher(s.server.URL+"/config.json", &SampleConfig{}, time.Hour)
+	s.Nil(err)
+
+	called := false
+	w.OnError(func(err error) { called = true })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	s.NotNil(w.Reload(ctx))
+	s.False(called)
+	s.Equal(0, w.Health().ConsecutiveFailures)
+}
+
+func (s *WatcherSuite) TestReloadUnchanged() {
+	etag := `"v1"`
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", etag)
+		fmt.Fprint(w, validConfigJSON)
+	}))
+	defer ts.Close()
+
+	w, err := NewWatcher(ts.URL+"/config.json", &SampleConfig{}, time.Hour)
+	s.Nil(err)
+	s.Nil(w.Metadata())
+	all, _ := subscribeChanges(w, "")
+
+	s.Nil(w.Start(context.Background()))
+	defer w.Stop()
+	s.Equal(etag, w.Metadata().ETag)
+
+	current := w.Current()
+	lastSuccess := w.Health().LastSuccess
+	s.Nil(w.Reload(context.Background()))
+
+	// An unchanged document keeps the current config and counts as a successful reload
+	s.True(current == w.Current())
+	s.False(w.Health().LastSuccess.Before(lastSuccess))
+	s.Len(all, 0)
+	s.Equal(etag, w.Metadata().ETag)
+}